@@ -10,6 +10,9 @@ import (
 	"context"
 	"fmt"
 	"net"
+	"os"
+	"os/signal"
+	"syscall"
 
 	"github.com/Multy-io/Multy-EOS-node-service"
 	"github.com/Multy-io/Multy-EOS-node-service/eos"
@@ -31,7 +34,12 @@ var (
 )
 
 var globalOpt = eosservice.Configuration{
-	Name: "eos-service",
+	Name:               "eos-service",
+	DBPath:             "eos-service.db",
+	NewTxBufferSize:    100,
+	SlowConsumerPolicy: string(eos.PolicyBlock),
+	ResyncWorkers:      2,
+	ResyncBatchSize:    10,
 }
 
 func main() {
@@ -45,21 +53,62 @@ func main() {
 		Commit:    commit,
 		Buildtime: buildtime,
 	}
-	initService(globalOpt)
-
-	block := make(chan bool)
-	<-block
+	err := initService(globalOpt)
+	if err != nil {
+		log.Errorf("service stopped: %s", err)
+		os.Exit(1)
+	}
+	log.Infof("service stopped")
 }
 
 func initService(conf eosservice.Configuration) error {
-	server := eos.NewServer(
+	storage, err := eos.NewBoltUsersStorage(conf.DBPath)
+	if err != nil {
+		return cli.NewExitError(fmt.Sprintf("cannot open storage: %s", err), 2)
+	}
+	server, err := eos.NewServer(
 		conf.RPC,
 		conf.P2P,
+		storage,
 	)
-	err := server.SetSigner(conf.Account, conf.Key)
 	if err != nil {
+		storage.Close()
 		return cli.NewExitError(fmt.Sprintf("cannot init server: %s", err), 2)
 	}
+	defer storage.Close()
+	err = server.SetSigner(conf.Account, conf.Key)
+	if err != nil {
+		return cli.NewExitError(fmt.Sprintf("cannot init server: %s", err), 2)
+	}
+	policy, err := eos.ParseSlowConsumerPolicy(conf.SlowConsumerPolicy)
+	if err != nil {
+		return cli.NewExitError(fmt.Sprintf("cannot init server: %s", err), 2)
+	}
+	if conf.BlockArchivePath != "" {
+		archive, err := eos.NewBoltBlockArchive(conf.BlockArchivePath, conf.BlockArchiveRetention)
+		if err != nil {
+			return cli.NewExitError(fmt.Sprintf("cannot open block archive: %s", err), 2)
+		}
+		defer archive.Close()
+		server.SetBlockArchive(archive)
+	}
+	if conf.ActionIndexPath != "" {
+		index, err := eos.NewBoltActionIndex(conf.ActionIndexPath)
+		if err != nil {
+			return cli.NewExitError(fmt.Sprintf("cannot open action index: %s", err), 2)
+		}
+		defer index.Close()
+		server.SetActionIndex(index)
+	}
+	err = server.SetNewTxPolicy(conf.NewTxBufferSize, policy)
+	if err != nil {
+		return cli.NewExitError(fmt.Sprintf("bad NewTxBufferSize: %s", err), 2)
+	}
+	server.SetResyncLimits(conf.ResyncWorkers, conf.ResyncBatchSize)
+	server.SetTokenContracts(conf.TokenContracts, conf.IgnoredTokenContracts)
+	server.SetCoreSymbol(conf.CoreSymbol)
+	server.SetActionTraces(conf.ActionTraces)
+	server.SetExecutedOnly(conf.ExecutedOnly)
 	server.SetVersion(branch, commit, buildtime, lasttag)
 	log.Infof("new server")
 
@@ -76,7 +125,24 @@ func initService(conf eosservice.Configuration) error {
 	s := grpc.NewServer()
 	pb.RegisterNodeCommunicationsServer(s, server)
 
+	server.Start()
+
+	// graceful shutdown: streams are closed by server
+	// and then gRPC waits for pending calls
+	signals := make(chan os.Signal, 1)
+	signal.Notify(signals, syscall.SIGINT, syscall.SIGTERM)
+	go func() {
+		sig := <-signals
+		log.Infof("got %s, shutting down", sig)
+		server.Close()
+		s.GracefulStop()
+	}()
+
 	log.Infof("listening on %s", addr)
 	err = s.Serve(lis)
-	return cli.NewExitError(err, 3)
+	if err != nil {
+		server.Close()
+		return cli.NewExitError(err, 3)
+	}
+	return nil
 }
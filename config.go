@@ -3,12 +3,31 @@ package eosservice
 import "github.com/Multy-io/Multy-back/store"
 
 type Configuration struct {
-	Name        string
-	Account     string
-	Key         string
-	Host        string
-	Port        string
-	RPC         string
-	P2P         string
+	Name    string
+	Account string
+	Key     string
+	Host    string
+	Port    string
+	RPC     string
+	P2P     string
+	DBPath  string // BoltDB file for tracked users
+
+	BlockArchivePath      string // BoltDB file for blocks archive, empty to disable
+	BlockArchiveRetention uint32 // number of latest blocks to keep, 0 for all
+	ActionIndexPath       string // BoltDB file for delivered actions, empty to disable
+
+	NewTxBufferSize    int    // actions buffer size of every NewTx stream, at least 1
+	SlowConsumerPolicy string // block, drop-oldest or disconnect
+
+	ResyncWorkers   int // concurrent resync block scans
+	ResyncBatchSize int // max resync jobs in one block scan
+
+	TokenContracts        []string // token contracts to track transfers of, empty for all
+	IgnoredTokenContracts []string // token contracts not to track
+	CoreSymbol            string   // core token symbol, EOS if empty
+
+	ActionTraces bool // get inline actions from history api, one request per transaction
+	ExecutedOnly bool // skip actions of failed, delayed and expired transactions
+
 	ServiceInfo store.ServiceInfo
 }
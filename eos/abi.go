@@ -0,0 +1,150 @@
+/*
+ * Copyright 2018 Idealnaya rabota LLC
+ * Licensed under Multy.io license.
+ * See LICENSE for details
+ */
+
+package eos
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/eoscanada/eos-go"
+)
+
+const (
+	// abiCacheTTL is a time contract ABI is kept for,
+	// as contract may change its ABI with setabi
+	abiCacheTTL = 10 * time.Minute
+	// abiRequestTimeout limits get_abi request,
+	// so slow node doesn't stall block processing
+	abiRequestTimeout = 5 * time.Second
+)
+
+// contractABI is a contract ABI indexed for decoding
+type contractABI struct {
+	// types are typedefs
+	types   map[string]string
+	structs map[string]abiStruct
+	// actions are action names to action data struct names
+	actions map[eos.ActionName]string
+}
+
+type abiStruct struct {
+	Name   string     `json:"name"`
+	Base   string     `json:"base"`
+	Fields []abiField `json:"fields"`
+}
+
+type abiField struct {
+	Name string `json:"name"`
+	Type string `json:"type"`
+}
+
+// abiCache fetches contracts ABIs with get_abi and keeps them for abiCacheTTL.
+// Fetch errors are kept too not to request node on every action
+type abiCache struct {
+	rpcAddr string
+	client  *http.Client
+
+	mu   sync.Mutex
+	abis map[eos.AccountName]*cachedABI
+}
+
+type cachedABI struct {
+	abi     *contractABI
+	err     error
+	fetched time.Time
+}
+
+func newABICache(rpcAddr string) *abiCache {
+	return &abiCache{
+		rpcAddr: rpcAddr,
+		client:  &http.Client{Timeout: abiRequestTimeout},
+		abis:    make(map[eos.AccountName]*cachedABI),
+	}
+}
+
+// Get gets contract ABI from cache or node
+func (cache *abiCache) Get(contract eos.AccountName) (*contractABI, error) {
+	cache.mu.Lock()
+	cached, ok := cache.abis[contract]
+	cache.mu.Unlock()
+	if ok && time.Since(cached.fetched) < abiCacheTTL {
+		return cached.abi, cached.err
+	}
+
+	abi, err := cache.fetch(contract)
+	cache.mu.Lock()
+	cache.abis[contract] = &cachedABI{
+		abi:     abi,
+		err:     err,
+		fetched: time.Now(),
+	}
+	cache.mu.Unlock()
+	return abi, err
+}
+
+// fetch gets contract ABI from node, it's raw request
+// as only types, structs and actions are needed
+func (cache *abiCache) fetch(contract eos.AccountName) (*contractABI, error) {
+	reqJSON, err := json.Marshal(map[string]string{
+		"account_name": string(contract),
+	})
+	if err != nil {
+		return nil, err
+	}
+	resp, err := cache.client.Post(fmt.Sprintf("%s/v1/chain/get_abi", cache.rpcAddr),
+		"application/json", bytes.NewReader(reqJSON))
+	if err != nil {
+		return nil, fmt.Errorf("get_abi: %s", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		bs, _ := ioutil.ReadAll(resp.Body)
+		return nil, fmt.Errorf("get_abi: response not ok: %v", string(bs))
+	}
+
+	var abiResp struct {
+		ABI *struct {
+			Types []struct {
+				NewTypeName string `json:"new_type_name"`
+				Type        string `json:"type"`
+			} `json:"types"`
+			Structs []abiStruct `json:"structs"`
+			Actions []struct {
+				Name eos.ActionName `json:"name"`
+				Type string         `json:"type"`
+			} `json:"actions"`
+		} `json:"abi"`
+	}
+	err = json.NewDecoder(resp.Body).Decode(&abiResp)
+	if err != nil {
+		return nil, fmt.Errorf("get_abi: %s", err)
+	}
+	if abiResp.ABI == nil {
+		return nil, fmt.Errorf("%s has no abi", contract)
+	}
+
+	abi := &contractABI{
+		types:   make(map[string]string, len(abiResp.ABI.Types)),
+		structs: make(map[string]abiStruct, len(abiResp.ABI.Structs)),
+		actions: make(map[eos.ActionName]string, len(abiResp.ABI.Actions)),
+	}
+	for _, typ := range abiResp.ABI.Types {
+		abi.types[typ.NewTypeName] = typ.Type
+	}
+	for _, s := range abiResp.ABI.Structs {
+		abi.structs[s.Name] = s
+	}
+	for _, action := range abiResp.ABI.Actions {
+		abi.actions[action.Name] = action.Type
+	}
+	return abi, nil
+}
@@ -0,0 +1,358 @@
+/*
+ * Copyright 2018 Idealnaya rabota LLC
+ * Licensed under Multy.io license.
+ * See LICENSE for details
+ */
+
+package eos
+
+import (
+	"encoding/binary"
+	"encoding/hex"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/eoscanada/eos-go"
+	"github.com/eoscanada/eos-go/ecc"
+)
+
+// maxABIDepth limits nesting of ABI types and typedefs chains
+const maxABIDepth = 32
+
+// blockTimestampEpoch is block_timestamp_type epoch in milliseconds
+const blockTimestampEpoch = 946684800000
+
+// DecodeAction decodes action binary data to json ready value.
+// Values of name fields are returned as well
+func (abi *contractABI) DecodeAction(name eos.ActionName, data []byte) (interface{}, []string, error) {
+	typ, ok := abi.actions[name]
+	if !ok {
+		return nil, nil, fmt.Errorf("action %s is not in abi", name)
+	}
+	decoder := &abiDecoder{
+		abi:  abi,
+		data: data,
+	}
+	value, err := decoder.decode(typ, 0)
+	if err != nil {
+		return nil, nil, fmt.Errorf("decode %s: %s", name, err)
+	}
+	return value, decoder.names, nil
+}
+
+// abiDecoder decodes binary data using contract ABI
+type abiDecoder struct {
+	abi  *contractABI
+	data []byte
+	pos  int
+	// names are values of decoded name fields
+	names []string
+}
+
+func (d *abiDecoder) decode(typ string, depth int) (interface{}, error) {
+	if depth > maxABIDepth {
+		return nil, fmt.Errorf("%s: too deep", typ)
+	}
+	if strings.HasSuffix(typ, "[]") {
+		count, err := d.readVarUint32()
+		if err != nil {
+			return nil, err
+		}
+		// every element takes at least one byte
+		if int(count) > len(d.data)-d.pos {
+			return nil, fmt.Errorf("%s: bad length %d", typ, count)
+		}
+		values := make([]interface{}, 0, count)
+		for i := uint32(0); i < count; i++ {
+			value, err := d.decode(strings.TrimSuffix(typ, "[]"), depth+1)
+			if err != nil {
+				return nil, err
+			}
+			values = append(values, value)
+		}
+		return values, nil
+	}
+	if strings.HasSuffix(typ, "?") {
+		present, err := d.read(1)
+		if err != nil {
+			return nil, err
+		}
+		if present[0] == 0 {
+			return nil, nil
+		}
+		return d.decode(strings.TrimSuffix(typ, "?"), depth+1)
+	}
+	if alias, ok := d.abi.types[typ]; ok {
+		return d.decode(alias, depth+1)
+	}
+	if s, ok := d.abi.structs[typ]; ok {
+		fields := make(map[string]interface{}, len(s.Fields))
+		err := d.decodeStruct(s, fields, depth)
+		return fields, err
+	}
+	return d.decodeBuiltin(typ)
+}
+
+// decodeStruct decodes base struct fields and then own ones
+func (d *abiDecoder) decodeStruct(s abiStruct, fields map[string]interface{}, depth int) error {
+	if s.Base != "" {
+		base, ok := d.abi.structs[s.Base]
+		if !ok {
+			return fmt.Errorf("%s: unknown base %s", s.Name, s.Base)
+		}
+		if depth > maxABIDepth {
+			return fmt.Errorf("%s: too deep", s.Name)
+		}
+		err := d.decodeStruct(base, fields, depth+1)
+		if err != nil {
+			return err
+		}
+	}
+	for _, field := range s.Fields {
+		value, err := d.decode(field.Type, depth+1)
+		if err != nil {
+			return fmt.Errorf("%s.%s: %s", s.Name, field.Name, err)
+		}
+		fields[field.Name] = value
+	}
+	return nil
+}
+
+func (d *abiDecoder) decodeBuiltin(typ string) (interface{}, error) {
+	switch typ {
+	case "bool":
+		b, err := d.read(1)
+		if err != nil {
+			return nil, err
+		}
+		return b[0] != 0, nil
+	case "int8":
+		b, err := d.read(1)
+		if err != nil {
+			return nil, err
+		}
+		return int8(b[0]), nil
+	case "uint8":
+		b, err := d.read(1)
+		if err != nil {
+			return nil, err
+		}
+		return b[0], nil
+	case "int16", "uint16":
+		b, err := d.read(2)
+		if err != nil {
+			return nil, err
+		}
+		v := binary.LittleEndian.Uint16(b)
+		if typ == "int16" {
+			return int16(v), nil
+		}
+		return v, nil
+	case "int32", "uint32":
+		b, err := d.read(4)
+		if err != nil {
+			return nil, err
+		}
+		v := binary.LittleEndian.Uint32(b)
+		if typ == "int32" {
+			return int32(v), nil
+		}
+		return v, nil
+	case "int64", "uint64":
+		v, err := d.readUint64()
+		if err != nil {
+			return nil, err
+		}
+		if typ == "int64" {
+			return int64(v), nil
+		}
+		return v, nil
+	case "varuint32":
+		return d.readVarUint32()
+	case "varint32":
+		v, n := binary.Varint(d.data[d.pos:])
+		if n <= 0 || v > math.MaxInt32 || v < math.MinInt32 {
+			return nil, fmt.Errorf("bad varint32")
+		}
+		d.pos += n
+		return int32(v), nil
+	case "float32":
+		b, err := d.read(4)
+		if err != nil {
+			return nil, err
+		}
+		return math.Float32frombits(binary.LittleEndian.Uint32(b)), nil
+	case "float64":
+		v, err := d.readUint64()
+		if err != nil {
+			return nil, err
+		}
+		return math.Float64frombits(v), nil
+	case "int128", "uint128", "float128":
+		b, err := d.read(16)
+		if err != nil {
+			return nil, err
+		}
+		return hex.EncodeToString(b), nil
+	case "name", "account_name", "permission_name", "action_name", "table_name", "scope_name":
+		v, err := d.readUint64()
+		if err != nil {
+			return nil, err
+		}
+		name := eos.NameToString(v)
+		d.names = append(d.names, name)
+		return name, nil
+	case "string":
+		b, err := d.readBytes()
+		if err != nil {
+			return nil, err
+		}
+		return string(b), nil
+	case "bytes":
+		b, err := d.readBytes()
+		if err != nil {
+			return nil, err
+		}
+		return hex.EncodeToString(b), nil
+	case "checksum160", "checksum256", "checksum512":
+		size := map[string]int{"checksum160": 20, "checksum256": 32, "checksum512": 64}[typ]
+		b, err := d.read(size)
+		if err != nil {
+			return nil, err
+		}
+		return hex.EncodeToString(b), nil
+	case "public_key":
+		b, err := d.read(34)
+		if err != nil {
+			return nil, err
+		}
+		return ecc.PublicKey{Curve: ecc.CurveID(b[0]), Content: b[1:]}.String(), nil
+	case "signature":
+		b, err := d.read(66)
+		if err != nil {
+			return nil, err
+		}
+		return ecc.Signature{Curve: ecc.CurveID(b[0]), Content: b[1:]}.String(), nil
+	case "time_point":
+		v, err := d.readUint64()
+		if err != nil {
+			return nil, err
+		}
+		return time.Unix(0, int64(v)*int64(time.Microsecond)).UTC().Format("2006-01-02T15:04:05.000"), nil
+	case "time_point_sec", "time":
+		b, err := d.read(4)
+		if err != nil {
+			return nil, err
+		}
+		return time.Unix(int64(binary.LittleEndian.Uint32(b)), 0).UTC().Format("2006-01-02T15:04:05"), nil
+	case "block_timestamp_type":
+		b, err := d.read(4)
+		if err != nil {
+			return nil, err
+		}
+		ms := int64(binary.LittleEndian.Uint32(b))*500 + blockTimestampEpoch
+		return time.Unix(0, ms*int64(time.Millisecond)).UTC().Format("2006-01-02T15:04:05.000"), nil
+	case "symbol":
+		v, err := d.readUint64()
+		if err != nil {
+			return nil, err
+		}
+		return fmt.Sprintf("%d,%s", uint8(v), symbolCode(v>>8)), nil
+	case "symbol_code":
+		v, err := d.readUint64()
+		if err != nil {
+			return nil, err
+		}
+		return symbolCode(v), nil
+	case "asset":
+		return d.readAsset()
+	case "extended_asset":
+		quantity, err := d.readAsset()
+		if err != nil {
+			return nil, err
+		}
+		contract, err := d.readUint64()
+		if err != nil {
+			return nil, err
+		}
+		return map[string]interface{}{
+			"quantity": quantity,
+			"contract": eos.NameToString(contract),
+		}, nil
+	}
+	return nil, fmt.Errorf("unknown type %s", typ)
+}
+
+func (d *abiDecoder) read(n int) ([]byte, error) {
+	if n > len(d.data)-d.pos {
+		return nil, fmt.Errorf("unexpected end of data")
+	}
+	b := d.data[d.pos : d.pos+n]
+	d.pos += n
+	return b, nil
+}
+
+func (d *abiDecoder) readUint64() (uint64, error) {
+	b, err := d.read(8)
+	if err != nil {
+		return 0, err
+	}
+	return binary.LittleEndian.Uint64(b), nil
+}
+
+func (d *abiDecoder) readVarUint32() (uint32, error) {
+	v, n := binary.Uvarint(d.data[d.pos:])
+	if n <= 0 || v > math.MaxUint32 {
+		return 0, fmt.Errorf("bad varuint32")
+	}
+	d.pos += n
+	return uint32(v), nil
+}
+
+func (d *abiDecoder) readBytes() ([]byte, error) {
+	size, err := d.readVarUint32()
+	if err != nil {
+		return nil, err
+	}
+	return d.read(int(size))
+}
+
+// readAsset reads asset formatting it like node does: "1.0000 EOS"
+func (d *abiDecoder) readAsset() (string, error) {
+	amount, err := d.readUint64()
+	if err != nil {
+		return "", err
+	}
+	symbol, err := d.readUint64()
+	if err != nil {
+		return "", err
+	}
+	precision := int(uint8(symbol))
+
+	sign := ""
+	abs := amount
+	if int64(amount) < 0 {
+		sign = "-"
+		abs = uint64(-int64(amount))
+	}
+	str := strconv.FormatUint(abs, 10)
+	if precision > 0 {
+		if len(str) <= precision {
+			str = strings.Repeat("0", precision-len(str)+1) + str
+		}
+		str = str[:len(str)-precision] + "." + str[len(str)-precision:]
+	}
+	return fmt.Sprintf("%s%s %s", sign, str, symbolCode(symbol>>8)), nil
+}
+
+// symbolCode gets symbol chars packed in uint64
+func symbolCode(v uint64) string {
+	var code []byte
+	for ; v != 0; v >>= 8 {
+		code = append(code, byte(v))
+	}
+	return string(code)
+}
@@ -0,0 +1,157 @@
+/*
+ * Copyright 2018 Idealnaya rabota LLC
+ * Licensed under Multy.io license.
+ * See LICENSE for details
+ */
+
+package eos
+
+import (
+	"bytes"
+	"encoding/binary"
+	"math"
+	"reflect"
+	"testing"
+
+	"github.com/eoscanada/eos-go"
+)
+
+// testABI has structs and typedefs used by decoder tests
+var testABI = &contractABI{
+	types: map[string]string{
+		"amount": "int64",
+		"loop":   "loop",
+	},
+	structs: map[string]abiStruct{
+		"parent": {Name: "parent", Fields: []abiField{{Name: "a", Type: "uint8"}}},
+		"child":  {Name: "child", Base: "parent", Fields: []abiField{{Name: "b", Type: "amount"}}},
+		"orphan": {Name: "orphan", Base: "missing"},
+		"self":   {Name: "self", Base: "self"},
+		"node":   {Name: "node", Fields: []abiField{{Name: "next", Type: "node?"}}},
+	},
+}
+
+// le encodes values little endian one after another
+func le(values ...interface{}) []byte {
+	var buf bytes.Buffer
+	for _, v := range values {
+		binary.Write(&buf, binary.LittleEndian, v)
+	}
+	return buf.Bytes()
+}
+
+// testSymbol packs precision and symbol code like node does
+func testSymbol(precision uint8, code string) uint64 {
+	v := uint64(precision)
+	for i := 0; i < len(code); i++ {
+		v |= uint64(code[i]) << (8 * uint(i+1))
+	}
+	return v
+}
+
+func TestABIDecoder(t *testing.T) {
+	cases := []struct {
+		name string
+		typ  string
+		data []byte
+		want interface{}
+	}{
+		{"bool", "bool", []byte{1}, true},
+		{"int16", "int16", le(int16(-2)), int16(-2)},
+		{"typedef", "amount", le(int64(-5)), int64(-5)},
+		{"varuint32 one byte", "varuint32", []byte{0x7f}, uint32(127)},
+		{"varuint32 two bytes", "varuint32", []byte{0x80, 0x01}, uint32(128)},
+		{"varuint32 max", "varuint32", []byte{0xff, 0xff, 0xff, 0xff, 0x0f}, uint32(math.MaxUint32)},
+		{"varint32 negative", "varint32", []byte{0x01}, int32(-1)},
+		{"varint32 positive", "varint32", []byte{0x80, 0x01}, int32(64)},
+		{"optional absent", "uint8?", []byte{0}, nil},
+		{"optional present", "uint8?", []byte{1, 7}, uint8(7)},
+		{"array", "uint16[]", le(uint8(2), uint16(1), uint16(2)), []interface{}{uint16(1), uint16(2)}},
+		{"empty array", "uint16[]", []byte{0}, []interface{}{}},
+		{"array of optionals", "uint8?[]", []byte{2, 0, 1, 3}, []interface{}{nil, uint8(3)}},
+		{"string", "string", []byte{2, 'h', 'i'}, "hi"},
+		{"base struct", "child", le(uint8(1), int64(2)), map[string]interface{}{"a": uint8(1), "b": int64(2)}},
+		{"recursive struct", "node", []byte{1, 1, 0}, map[string]interface{}{
+			"next": map[string]interface{}{"next": map[string]interface{}{"next": nil}},
+		}},
+		{"asset", "asset", le(int64(10000), testSymbol(4, "EOS")), "1.0000 EOS"},
+		{"negative asset", "asset", le(int64(-1), testSymbol(4, "EOS")), "-0.0001 EOS"},
+		{"negative asset with int part", "asset", le(int64(-12345), testSymbol(4, "EOS")), "-1.2345 EOS"},
+		{"padded asset", "asset", le(int64(10), testSymbol(4, "EOS")), "0.0010 EOS"},
+		{"zero asset", "asset", le(int64(0), testSymbol(4, "EOS")), "0.0000 EOS"},
+		{"asset without precision", "asset", le(int64(5), testSymbol(0, "SYS")), "5 SYS"},
+		{"min asset", "asset", le(int64(math.MinInt64), testSymbol(4, "EOS")), "-922337203685477.5808 EOS"},
+		{"symbol", "symbol", le(testSymbol(4, "EOS")), "4,EOS"},
+	}
+	for _, c := range cases {
+		d := &abiDecoder{abi: testABI, data: c.data}
+		got, err := d.decode(c.typ, 0)
+		if err != nil {
+			t.Errorf("%s: %s", c.name, err)
+			continue
+		}
+		if !reflect.DeepEqual(got, c.want) {
+			t.Errorf("%s: got %#v, want %#v", c.name, got, c.want)
+		}
+		if d.pos != len(c.data) {
+			t.Errorf("%s: %d of %d bytes read", c.name, d.pos, len(c.data))
+		}
+
+		// every truncated data must fail
+		for n := 0; n < len(c.data); n++ {
+			d := &abiDecoder{abi: testABI, data: c.data[:n]}
+			if got, err := d.decode(c.typ, 0); err == nil {
+				t.Errorf("%s: %d of %d bytes are decoded to %#v", c.name, n, len(c.data), got)
+			}
+		}
+	}
+}
+
+func TestABIDecoderErrors(t *testing.T) {
+	deep := "uint8"
+	for i := 0; i < maxABIDepth+1; i++ {
+		deep += "?"
+	}
+	cases := []struct {
+		name string
+		typ  string
+		data []byte
+	}{
+		{"empty varuint32", "varuint32", nil},
+		{"unterminated varuint32", "varuint32", []byte{0x80, 0x80}},
+		{"too big varuint32", "varuint32", []byte{0xff, 0xff, 0xff, 0xff, 0x7f}},
+		{"empty varint32", "varint32", nil},
+		{"too big varint32", "varint32", []byte{0xff, 0xff, 0xff, 0xff, 0x7f}},
+		{"truncated optional", "uint8?", []byte{1}},
+		{"missing optional flag", "uint8?", nil},
+		{"array longer than data", "uint8[]", []byte{0xff, 0xff, 0xff, 0xff, 0x0f}},
+		{"truncated array", "uint16[]", []byte{2, 1, 0, 2}},
+		{"truncated string", "string", []byte{5, 'h'}},
+		{"string longer than data", "string", []byte{0xff, 0xff, 0xff, 0xff, 0x0f}},
+		{"truncated struct", "child", []byte{1, 2, 0}},
+		{"unknown base", "orphan", nil},
+		{"self base", "self", nil},
+		{"typedef loop", "loop", nil},
+		{"too deep optionals", deep, bytes.Repeat([]byte{1}, maxABIDepth+2)},
+		{"too deep struct", "node", bytes.Repeat([]byte{1}, 2*maxABIDepth)},
+		{"truncated asset", "asset", le(int64(1), uint32(4))},
+		{"truncated public key", "public_key", make([]byte, 33)},
+		{"truncated signature", "signature", make([]byte, 65)},
+		{"truncated checksum", "checksum256", make([]byte, 31)},
+		{"unknown type", "uint256", make([]byte, 32)},
+	}
+	for _, c := range cases {
+		d := &abiDecoder{abi: testABI, data: c.data}
+		got, err := d.decode(c.typ, 0)
+		if err == nil {
+			t.Errorf("%s: got %#v, want error", c.name, got)
+		}
+	}
+}
+
+func TestDecodeActionUnknownAction(t *testing.T) {
+	abi := &contractABI{actions: map[eos.ActionName]string{"hi": "parent"}}
+	if _, _, err := abi.DecodeAction("bye", []byte{1}); err == nil {
+		t.Error("unknown action is decoded")
+	}
+}
@@ -0,0 +1,282 @@
+/*
+ * Copyright 2018 Idealnaya rabota LLC
+ * Licensed under Multy.io license.
+ * See LICENSE for details
+ */
+
+package eos
+
+import (
+	"bytes"
+	"encoding/binary"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/Multy-io/Multy-EOS-node-service/proto"
+	"github.com/boltdb/bolt"
+)
+
+const (
+	// indexQueueSize is a number of actions waiting to be written
+	indexQueueSize = 1000
+	// indexBatchSize is a max number of actions written in one transaction
+	indexBatchSize = 100
+	// defaultHistoryLimit is a default account history page size
+	defaultHistoryLimit = 50
+	// maxHistoryLimit is a max account history page size
+	maxHistoryLimit = 1000
+)
+
+// ActionIndex keeps delivered actions of tracked accounts
+type ActionIndex interface {
+	// Put queues action for storing, reverted action is removed
+	Put(action proto.Action)
+	// History gets account's actions from the latest to the oldest
+	History(req *proto.AccountHistoryReq) (*proto.AccountHistory, error)
+	// Close writes queued actions and releases index resources
+	Close() error
+}
+
+var actionsBucket = []byte("actions")
+
+// BoltActionIndex is an ActionIndex kept in BoltDB file.
+// Every account has own bucket with actions keyed by chain position
+type BoltActionIndex struct {
+	db *bolt.DB
+
+	queue chan proto.Action
+	// done is closed on Close, closed is closed when writer is stopped
+	done   chan struct{}
+	closed chan struct{}
+}
+
+// NewBoltActionIndex opens (or creates) BoltDB file on path
+func NewBoltActionIndex(path string) (*BoltActionIndex, error) {
+	db, err := bolt.Open(path, 0600, &bolt.Options{Timeout: time.Second})
+	if err != nil {
+		return nil, fmt.Errorf("open %s: %s", path, err)
+	}
+	err = db.Update(func(tx *bolt.Tx) error {
+		_, err := tx.CreateBucketIfNotExists(actionsBucket)
+		return err
+	})
+	if err != nil {
+		db.Close()
+		return nil, fmt.Errorf("create bucket: %s", err)
+	}
+	index := &BoltActionIndex{
+		db:     db,
+		queue:  make(chan proto.Action, indexQueueSize),
+		done:   make(chan struct{}),
+		closed: make(chan struct{}),
+	}
+	go index.run()
+	return index, nil
+}
+
+// Put queues action, it blocks if queue is full
+func (index *BoltActionIndex) Put(action proto.Action) {
+	select {
+	case <-index.done:
+	case index.queue <- action:
+	}
+}
+
+// History gets account's actions matching request filters
+func (index *BoltActionIndex) History(req *proto.AccountHistoryReq) (*proto.AccountHistory, error) {
+	limit := int(req.Limit)
+	if limit == 0 {
+		limit = defaultHistoryLimit
+	}
+	if limit > maxHistoryLimit {
+		limit = maxHistoryLimit
+	}
+	var after []byte
+	if req.Cursor != "" {
+		var err error
+		after, err = hex.DecodeString(req.Cursor)
+		if err != nil {
+			return nil, fmt.Errorf("bad cursor: %s", err)
+		}
+	}
+
+	history := &proto.AccountHistory{}
+	// lastKey is a key of the last action in the page
+	var lastKey []byte
+	err := index.db.View(func(tx *bolt.Tx) error {
+		bucket := tx.Bucket(actionsBucket).Bucket([]byte(req.Address))
+		if bucket == nil {
+			return nil
+		}
+		c := bucket.Cursor()
+		var k, v []byte
+		switch {
+		case after != nil:
+			k, v = c.Seek(after)
+			if k == nil {
+				k, v = c.Last()
+			}
+			for k != nil && bytes.Compare(k, after) >= 0 {
+				k, v = c.Prev()
+			}
+		case req.EndBlock != 0:
+			k, v = c.Seek(blockKey(req.EndBlock + 1))
+			if k == nil {
+				k, v = c.Last()
+			} else {
+				k, v = c.Prev()
+			}
+		default:
+			k, v = c.Last()
+		}
+
+		for ; k != nil; k, v = c.Prev() {
+			if binary.BigEndian.Uint32(k) < req.StartBlock {
+				break
+			}
+			if len(history.Actions) == limit {
+				history.NextCursor = hex.EncodeToString(lastKey)
+				break
+			}
+			var action proto.Action
+			err := json.Unmarshal(v, &action)
+			if err != nil {
+				return fmt.Errorf("action %x: %s", k, err)
+			}
+			if !historyMatches(req, &action) {
+				continue
+			}
+			history.Actions = append(history.Actions, &action)
+			lastKey = append(lastKey[:0], k...)
+		}
+		return nil
+	})
+	return history, err
+}
+
+// Close writes queued actions and closes db
+func (index *BoltActionIndex) Close() error {
+	close(index.done)
+	<-index.closed
+	return index.db.Close()
+}
+
+func (index *BoltActionIndex) run() {
+	defer close(index.closed)
+	for {
+		select {
+		case action := <-index.queue:
+			index.write(action)
+		case <-index.done:
+			for {
+				select {
+				case action := <-index.queue:
+					index.write(action)
+				default:
+					return
+				}
+			}
+		}
+	}
+}
+
+// write writes action with the rest of queued actions in one transaction
+func (index *BoltActionIndex) write(action proto.Action) {
+	actions := []proto.Action{action}
+collect:
+	for len(actions) < indexBatchSize {
+		select {
+		case action := <-index.queue:
+			actions = append(actions, action)
+		default:
+			break collect
+		}
+	}
+
+	err := index.db.Update(func(tx *bolt.Tx) error {
+		root := tx.Bucket(actionsBucket)
+		for _, action := range actions {
+			if action.ActionIndex < 0 {
+				// action position in block is unknown, its key would collide
+				// with other actions and differ from the key of the same action
+				// got from blocks, so it's not indexed
+				continue
+			}
+			bucket, err := root.CreateBucketIfNotExists([]byte(action.Address))
+			if err != nil {
+				return err
+			}
+			key, err := actionKey(&action)
+			if err != nil {
+				log.Errorf("action index: %s: %s", action.Address, err)
+				continue
+			}
+			if action.Status == proto.Action_REVERTED {
+				err = bucket.Delete(key)
+				if err != nil {
+					return err
+				}
+				continue
+			}
+			// action is stored once for all the wallets
+			action.UserID = ""
+			action.WalletIndex = 0
+			action.AddressIndex = 0
+			action.Resync = false
+			action.Status = proto.Action_PENDING
+			data, err := json.Marshal(&action)
+			if err != nil {
+				return err
+			}
+			err = bucket.Put(key, data)
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		log.Errorf("action index: write: %s", err)
+	}
+}
+
+// actionKey is an action position in chain and number of its sending
+// to the account followed by transaction id. Account may get action
+// at the same position several times, e.g. both legs of self transfer
+func actionKey(action *proto.Action) ([]byte, error) {
+	pos, err := parseCursor(action.Cursor)
+	if err != nil {
+		return nil, err
+	}
+	key := make([]byte, 16, 16+len(action.TransactionId))
+	binary.BigEndian.PutUint32(key[0:], pos.blockNum)
+	binary.BigEndian.PutUint32(key[4:], pos.txIndex)
+	binary.BigEndian.PutUint32(key[8:], pos.actionIndex)
+	binary.BigEndian.PutUint32(key[12:], pos.seq)
+	return append(key, action.TransactionId...), nil
+}
+
+// historyMatches checks action by request filters
+func historyMatches(req *proto.AccountHistoryReq, action *proto.Action) bool {
+	if len(req.Types) != 0 {
+		found := false
+		for _, t := range req.Types {
+			if t == action.Type {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	if req.Symbol != "" && (action.Amount == nil || action.Amount.Symbol != req.Symbol) {
+		return false
+	}
+	if req.Contract != "" && action.Contract != req.Contract {
+		return false
+	}
+	return true
+}
@@ -0,0 +1,102 @@
+/*
+ * Copyright 2018 Idealnaya rabota LLC
+ * Licensed under Multy.io license.
+ * See LICENSE for details
+ */
+
+package eos
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/Multy-io/Multy-EOS-node-service/proto"
+)
+
+func testIndexAction(blockNum, seq uint32) proto.Action {
+	pos := cursor{blockNum: blockNum, actionIndex: 1, account: "alice", seq: seq, wallet: UserData{UserID: "a"}}
+	return proto.Action{
+		Address:       "alice",
+		BlockNum:      blockNum,
+		Cursor:        pos.String(),
+		TransactionId: []byte{byte(blockNum)},
+		Memo:          pos.String(),
+	}
+}
+
+func TestBoltActionIndexHistory(t *testing.T) {
+	dir, err := ioutil.TempDir("", "action-index")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "actions.db")
+	index, err := NewBoltActionIndex(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var want []string
+	for num := uint32(1); num <= 5; num++ {
+		index.Put(testIndexAction(num, 0))
+		want = append(want, testIndexAction(num, 0).Memo)
+		if num == 3 {
+			// the second leg of self transfer at the same position
+			index.Put(testIndexAction(num, 1))
+			want = append(want, testIndexAction(num, 1).Memo)
+		}
+	}
+	// action of orphaned block is removed
+	index.Put(testIndexAction(6, 0))
+	reverted := testIndexAction(6, 0)
+	reverted.Status = proto.Action_REVERTED
+	index.Put(reverted)
+
+	// queued actions are written on close
+	err = index.Close()
+	if err != nil {
+		t.Fatal(err)
+	}
+	index, err = NewBoltActionIndex(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer index.Close()
+
+	var got []string
+	req := &proto.AccountHistoryReq{Address: "alice", Limit: 2}
+	for pages := 0; ; pages++ {
+		if pages > len(want) {
+			t.Fatal("pagination doesn't end")
+		}
+		history, err := index.History(req)
+		if err != nil {
+			t.Fatal(err)
+		}
+		for _, action := range history.Actions {
+			got = append(got, action.Memo)
+		}
+		if history.NextCursor == "" {
+			break
+		}
+		req.Cursor = history.NextCursor
+	}
+	if len(got) != len(want) {
+		t.Fatalf("got %d actions, want %d: %v", len(got), len(want), got)
+	}
+	for i := range want {
+		if got[i] != want[len(want)-1-i] {
+			t.Errorf("action %d is %s, want %s", i, got[i], want[len(want)-1-i])
+		}
+	}
+
+	history, err := index.History(&proto.AccountHistoryReq{Address: "alice", StartBlock: 3, EndBlock: 3})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(history.Actions) != 2 {
+		t.Errorf("got %d actions of block 3, want both self transfer legs", len(history.Actions))
+	}
+}
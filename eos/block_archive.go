@@ -0,0 +1,253 @@
+/*
+ * Copyright 2018 Idealnaya rabota LLC
+ * Licensed under Multy.io license.
+ * See LICENSE for details
+ */
+
+package eos
+
+import (
+	"encoding/binary"
+	"fmt"
+	"time"
+
+	"github.com/boltdb/bolt"
+	"github.com/eoscanada/eos-go"
+)
+
+const (
+	// archiveQueueSize is a number of blocks waiting to be written,
+	// Put blocks if queue is full
+	archiveQueueSize = 1000
+	// archiveBatchSize is a max number of blocks written in one transaction
+	archiveBatchSize = 100
+	// archivePruneInterval is an interval of old blocks removal
+	archivePruneInterval = 10 * time.Minute
+	// archiveReadSize is a number of blocks read from archive at once
+	archiveReadSize = 100
+)
+
+// BlockArchive keeps blocks locally to replay them without p2p
+type BlockArchive interface {
+	// Put queues block for writing, it blocks while queue is full
+	// so no block is missed
+	Put(block *eos.SignedBlock)
+	// Fork removes blocks starting with blockNum orphaned by fork,
+	// it's applied after blocks queued before
+	Fork(blockNum uint32)
+	// Blocks gets up to count stored blocks starting with startBlockNum,
+	// stopping at the first missing one
+	Blocks(startBlockNum uint32, count int) ([]*eos.SignedBlock, error)
+	// Close writes queued blocks and releases archive resources
+	Close() error
+}
+
+var blocksBucket = []byte("blocks")
+
+// archiveOp is a block to write or a fork to remove orphaned blocks of
+type archiveOp struct {
+	block *eos.SignedBlock
+	// forkBlockNum is the first orphaned block, it's set if block is nil
+	forkBlockNum uint32
+}
+
+// BoltBlockArchive is a BlockArchive kept in BoltDB file.
+// Blocks are keyed by number, blocks orphaned by fork are removed
+type BoltBlockArchive struct {
+	db *bolt.DB
+	// retention is a number of the latest blocks to keep, 0 to keep all
+	retention uint32
+
+	queue chan archiveOp
+	// done is closed on Close, closed is closed when writer is stopped
+	done   chan struct{}
+	closed chan struct{}
+}
+
+// NewBoltBlockArchive opens (or creates) BoltDB file on path
+func NewBoltBlockArchive(path string, retention uint32) (*BoltBlockArchive, error) {
+	db, err := bolt.Open(path, 0600, &bolt.Options{Timeout: time.Second})
+	if err != nil {
+		return nil, fmt.Errorf("open %s: %s", path, err)
+	}
+	err = db.Update(func(tx *bolt.Tx) error {
+		_, err := tx.CreateBucketIfNotExists(blocksBucket)
+		return err
+	})
+	if err != nil {
+		db.Close()
+		return nil, fmt.Errorf("create bucket: %s", err)
+	}
+	archive := &BoltBlockArchive{
+		db:        db,
+		retention: retention,
+		queue:     make(chan archiveOp, archiveQueueSize),
+		done:      make(chan struct{}),
+		closed:    make(chan struct{}),
+	}
+	go archive.run()
+	return archive, nil
+}
+
+// Put queues block for writing, it blocks if queue is full
+func (archive *BoltBlockArchive) Put(block *eos.SignedBlock) {
+	archive.queueOp(archiveOp{block: block})
+}
+
+// Fork queues removal of blocks starting with blockNum
+func (archive *BoltBlockArchive) Fork(blockNum uint32) {
+	archive.queueOp(archiveOp{forkBlockNum: blockNum})
+}
+
+func (archive *BoltBlockArchive) queueOp(op archiveOp) {
+	select {
+	case <-archive.done:
+	case archive.queue <- op:
+	}
+}
+
+// Blocks gets stored blocks starting with startBlockNum
+func (archive *BoltBlockArchive) Blocks(startBlockNum uint32, count int) ([]*eos.SignedBlock, error) {
+	var blocks []*eos.SignedBlock
+	err := archive.db.View(func(tx *bolt.Tx) error {
+		c := tx.Bucket(blocksBucket).Cursor()
+		num := startBlockNum
+		for k, v := c.Seek(blockKey(num)); k != nil && len(blocks) < count; k, v = c.Next() {
+			if binary.BigEndian.Uint32(k) != num {
+				return nil
+			}
+			block := &eos.SignedBlock{}
+			err := eos.UnmarshalBinary(v, block)
+			if err != nil {
+				return fmt.Errorf("block %d: %s", num, err)
+			}
+			blocks = append(blocks, block)
+			num++
+		}
+		return nil
+	})
+	return blocks, err
+}
+
+// Close writes queued blocks and closes db
+func (archive *BoltBlockArchive) Close() error {
+	close(archive.done)
+	<-archive.closed
+	return archive.db.Close()
+}
+
+func (archive *BoltBlockArchive) run() {
+	defer close(archive.closed)
+	ticker := time.NewTicker(archivePruneInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case op := <-archive.queue:
+			archive.write(op)
+		case <-ticker.C:
+			archive.prune()
+		case <-archive.done:
+			for {
+				select {
+				case op := <-archive.queue:
+					archive.write(op)
+				default:
+					return
+				}
+			}
+		}
+	}
+}
+
+// write applies op with the rest of queued ones in one transaction
+func (archive *BoltBlockArchive) write(op archiveOp) {
+	ops := []archiveOp{op}
+collect:
+	for len(ops) < archiveBatchSize {
+		select {
+		case op := <-archive.queue:
+			ops = append(ops, op)
+		default:
+			break collect
+		}
+	}
+
+	err := archive.db.Update(func(tx *bolt.Tx) error {
+		bucket := tx.Bucket(blocksBucket)
+		for _, op := range ops {
+			if op.block == nil {
+				err := removeBlocks(bucket, op.forkBlockNum)
+				if err != nil {
+					return err
+				}
+				continue
+			}
+			block := op.block
+			data, err := eos.MarshalBinary(block)
+			if err != nil {
+				return fmt.Errorf("block %d: %s", block.BlockNumber(), err)
+			}
+			err = bucket.Put(blockKey(block.BlockNumber()), data)
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		log.Errorf("block archive: write: %s", err)
+	}
+}
+
+// prune removes blocks older than retention
+func (archive *BoltBlockArchive) prune() {
+	if archive.retention == 0 {
+		return
+	}
+	var pruned int
+	err := archive.db.Update(func(tx *bolt.Tx) error {
+		c := tx.Bucket(blocksBucket).Cursor()
+		last, _ := c.Last()
+		if last == nil {
+			return nil
+		}
+		lastBlockNum := binary.BigEndian.Uint32(last)
+		if lastBlockNum <= archive.retention {
+			return nil
+		}
+		minBlockNum := lastBlockNum - archive.retention
+		for k, _ := c.First(); k != nil && binary.BigEndian.Uint32(k) < minBlockNum; k, _ = c.First() {
+			err := c.Delete()
+			if err != nil {
+				return err
+			}
+			pruned++
+		}
+		return nil
+	})
+	if err != nil {
+		log.Errorf("block archive: prune: %s", err)
+		return
+	}
+	if pruned != 0 {
+		log.Infof("block archive: pruned %d blocks", pruned)
+	}
+}
+
+// removeBlocks removes blocks starting with blockNum
+func removeBlocks(bucket *bolt.Bucket, blockNum uint32) error {
+	c := bucket.Cursor()
+	for k, _ := c.Seek(blockKey(blockNum)); k != nil; k, _ = c.Seek(blockKey(blockNum)) {
+		err := c.Delete()
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func blockKey(blockNum uint32) []byte {
+	key := make([]byte, 4)
+	binary.BigEndian.PutUint32(key, blockNum)
+	return key
+}
@@ -0,0 +1,60 @@
+/*
+ * Copyright 2018 Idealnaya rabota LLC
+ * Licensed under Multy.io license.
+ * See LICENSE for details
+ */
+
+package eos
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestBlockArchiveForkRemovesOrphanedBlocks(t *testing.T) {
+	dir, err := ioutil.TempDir("", "block-archive")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "blocks.db")
+
+	archive, err := NewBoltBlockArchive(path, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// more blocks than queue holds, none is dropped
+	for num := uint32(1); num <= archiveQueueSize+10; num++ {
+		archive.Put(testBlock(num))
+	}
+	// blocks from 5 are orphaned, new branch has 5 and 6 only
+	archive.Fork(5)
+	archive.Put(testBlock(5))
+	archive.Put(testBlock(6))
+	err = archive.Close()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	archive, err = NewBoltBlockArchive(path, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer archive.Close()
+	blocks, err := archive.Blocks(1, archiveQueueSize)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(blocks) != 6 {
+		t.Errorf("%d blocks are archived, want 6", len(blocks))
+	}
+	blocks, err = archive.Blocks(7, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(blocks) != 0 {
+		t.Error("orphaned block 7 is archived")
+	}
+}
@@ -8,9 +8,14 @@ package eos
 
 import (
 	"context"
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"sync/atomic"
 
 	"github.com/eoscanada/eos-go"
-	"github.com/eoscanada/eos-go/p2p"
+	"github.com/eoscanada/eos-go/msig"
 	"github.com/eoscanada/eos-go/system"
 	"github.com/eoscanada/eos-go/token"
 	"github.com/jekabolt/slf"
@@ -33,72 +38,201 @@ type blockDataHandler struct {
 	name         string
 	history      chan proto.Action
 	resync       bool
-	trackedUsers map[string]UserData
+	trackedUsers *trackedUsers
+	// tokens are token contracts to decode transfers of
+	tokens *tokenContracts
+	// abis are contracts ABIs to decode generic actions, may be nil
+	abis *abiCache
+	// traces is a source of inline actions, nil to process
+	// transactions' actions only
+	traces traceSource
+	// executedOnly skips failed, delayed and expired transactions
+	executedOnly bool
+	// deferred are delayed transactions waiting for execution, may be nil
+	deferred *deferredTxs
+	// proposals are msig proposals seen by handler, may be nil
+	proposals *msigProposals
+	// msigTable gets proposals made before handler start, may be nil
+	msigTable *proposalTable
 
-	//startBlockNum uint32
-	//endBlockNum uint32
+	// actionsSent is a number of actions sent to history
+	actionsSent uint64
 
-	blockNumCh chan uint32
+	// delivered keeps sent actions of recent blocks to revert them on fork,
+	// nil if handler doesn't handle forks
+	delivered map[uint32][]proto.Action
+	// queued are actions of the current position waiting for flush
+	queued []queuedAction
 }
 
-func (handler blockDataHandler) Handle(msg p2p.Message) {
+// queuedAction is an action waiting to be sent
+type queuedAction struct {
+	pos    cursor
+	action proto.Action
+}
+
+func (handler *blockDataHandler) HandleBlock(block *eos.SignedBlock) {
 	select {
 	case <-handler.ctx.Done():
 		return
 	default:
-		if msg.Envelope.Type == eos.SignedBlockType {
-			block := msg.Envelope.P2PMessage.(*eos.SignedBlock)
-			if num := block.BlockNumber(); num%10000 == 0 {
-				log.Debugf("process block %d", block.BlockNumber())
+	}
+	if num := block.BlockNumber(); num%10000 == 0 {
+		log.Debugf("process block %d", block.BlockNumber())
+	}
+	if handler.delivered != nil {
+		for blockNum := range handler.delivered {
+			if blockNum+forkWindow <= block.BlockNumber() {
+				delete(handler.delivered, blockNum)
 			}
-			if handler.blockNumCh != nil {
-				handler.blockNumCh <- block.BlockNumber()
+		}
+	}
+	handler.deferred.Prune(block.Timestamp.Time)
+	handler.proposals.Prune(block.Timestamp.Time)
+	// whole block is processed with the same users view
+	users := handler.trackedUsers.Snapshot()
+	blockTraces := handler.fetchTraces(block)
+	for txNum := range block.Transactions {
+		tx := &block.Transactions[txNum]
+		receipt, err := receiptAction(tx, block.BlockNumber())
+		if err != nil {
+			log.Debugf("%s (block %d, %s)", err, block.BlockNumber(), handler.name)
+			continue
+		}
+		if tx.Transaction.Packed == nil {
+			// deferred transaction has id only
+			handler.processDeferred(users, tx, uint32(txNum), receipt, blockTraces[txNum])
+			continue
+		}
+		unpacked, err := tx.Transaction.Packed.Unpack()
+		if err != nil {
+			log.Debugf("%s (block %d, %s)", err, block.BlockNumber(), handler.name)
+			continue
+		}
+		if tx.Status == eos.TransactionStatusDelayed {
+			receipt.DeferredStatus = proto.Action_DEFERRED_PENDING
+			handler.scheduleDeferred(users, tx.Transaction.ID, unpacked, block)
+		}
+		if handler.executedOnly && tx.Status != eos.TransactionStatusExecuted {
+			continue
+		}
+		if traces, ok := blockTraces[txNum]; ok {
+			handler.processTraces(users, traces, len(unpacked.ContextFreeActions), uint32(txNum), receipt)
+			continue
+		}
+		// context free actions are executed first, so they go first
+		// in action indexes as they do in traces
+		contextFree := len(unpacked.ContextFreeActions)
+		for idx, action := range unpacked.ContextFreeActions {
+			pos := cursor{
+				blockNum:    block.BlockNumber(),
+				txIndex:     uint32(txNum),
+				actionIndex: uint32(idx),
 			}
-			for txNum := range block.Transactions {
-				tx := &block.Transactions[txNum]
-				if tx.Transaction.Packed != nil {
-					unpacked, err := tx.Transaction.Packed.Unpack()
-					if err != nil {
-						log.Debugf("%s (block %d, %s)", err, block.BlockNumber(), handler.name)
-						continue
-					}
-					for idx, action := range unpacked.Actions {
-						go handler.processAction(action, block.BlockNumber(), tx.Transaction.ID, int64(idx))
-					}
-					// TODO: parse context free actions (once it will exist)
-				}
+			toSend := receipt
+			toSend.ActionIndex = int64(idx)
+			toSend.ContextFree = true
+			handler.mapAction(users, action, toSend, &pos)
+			handler.flush()
+		}
+		for idx, action := range unpacked.Actions {
+			pos := cursor{
+				blockNum:    block.BlockNumber(),
+				txIndex:     uint32(txNum),
+				actionIndex: uint32(contextFree + idx),
 			}
+			toSend := receipt
+			toSend.ActionIndex = int64(contextFree + idx)
+			handler.mapAction(users, action, toSend, &pos)
+			handler.flush()
 		}
+	}
+}
+
+// receiptAction makes action template with transaction receipt data
+func receiptAction(tx *eos.TransactionReceipt, blockNum uint32) (proto.Action, error) {
+	action := proto.Action{
+		TransactionId: tx.Transaction.ID,
+		BlockNum:      blockNum,
+		CpuUsageUs:    tx.CPUUsageMicroSeconds,
+		NetUsageWords: uint32(tx.NetUsageWords),
+	}
+	switch tx.Status {
+	case eos.TransactionStatusExecuted:
+		action.ReceiptStatus = proto.Action_EXECUTED
+	case eos.TransactionStatusSoftFail:
+		action.ReceiptStatus = proto.Action_SOFT_FAIL
+	case eos.TransactionStatusHardFail:
+		action.ReceiptStatus = proto.Action_HARD_FAIL
+	case eos.TransactionStatusDelayed:
+		action.ReceiptStatus = proto.Action_DELAYED
+	case eos.TransactionStatusExpired:
+		action.ReceiptStatus = proto.Action_EXPIRED
+	default:
+		return action, fmt.Errorf("transaction %x: unknown receipt status %d", tx.Transaction.ID, tx.Status)
+	}
+	return action, nil
+}
+
+// HandleFork sends delivered actions of orphaned blocks
+// with reverted status in reverse order
+func (handler *blockDataHandler) HandleFork(blockNum uint32) {
+	if handler.delivered == nil {
+		return
+	}
+	var orphaned []uint32
+	for num := range handler.delivered {
+		if num >= blockNum {
+			orphaned = append(orphaned, num)
+		}
+	}
+	sort.Slice(orphaned, func(i, j int) bool { return orphaned[i] > orphaned[j] })
+	for _, num := range orphaned {
+		actions := handler.delivered[num]
+		delete(handler.delivered, num)
+		for i := len(actions) - 1; i >= 0; i-- {
+			action := actions[i]
+			action.Status = proto.Action_REVERTED
+			select {
+			case <-handler.ctx.Done():
+				return
+			case handler.history <- action:
+			}
+		}
+	}
+}
 
+// processAction sends action got without its transaction,
+// so ActionIndex is unknown and it's -1. Action has no cursor
+// as it can't be told apart from other actions of the block
+func (handler *blockDataHandler) processAction(users usersView, action *eos.Action, pos cursor, transactionID eos.SHA256Bytes) {
+	pos.unknown = true
+	toSend := proto.Action{
+		ActionIndex:   -1,
+		TransactionId: transactionID,
+		BlockNum:      pos.blockNum,
 	}
+	handler.mapAction(users, action, toSend, &pos)
+	handler.flush()
 }
 
-func (handler *blockDataHandler) processAction(action *eos.Action, blockNum uint32, transactionID eos.SHA256Bytes, actionIndex int64) {
-	if action.Data != nil {
+// mapAction fills toSend with action data and sends it
+// to tracked accounts involved into action
+func (handler *blockDataHandler) mapAction(users usersView, action *eos.Action, toSend proto.Action, pos *cursor) {
+	if action.Data != nil || len(action.HexData) != 0 {
 		err := action.MapToRegisteredAction()
 		if err != nil {
 			log.Errorf("processAction:ction.MapToRegisteredAction %v", err.Error())
 			return
 		}
 
-		toSend := proto.Action{
-			ActionIndex:   actionIndex,
-			TransactionId: transactionID,
-			BlockNum:      blockNum,
-		}
+		toSend.Contract = string(action.Account)
 
 		// check for default smart-contracts' action
 		switch op := action.Data.(type) {
 		// eosio.token
 		case *token.Transfer:
-			toSend.Type = proto.Action_TRANSFER_TOKEN
-			toSend.From = string(op.From)
-			toSend.To = string(op.To)
-			toSend.Amount = asset(op.Quantity)
-			toSend.Memo = op.Memo
-
-			handler.sendHistory(toSend, op.From)
-			handler.sendHistory(toSend, op.To)
+			handler.sendTransfer(users, toSend, pos, op)
 		case *token.Issue:
 			toSend.Type = proto.Action_ISSUE_TOKEN
 			toSend.From = "eosio.token" // this is default token contract
@@ -106,7 +240,7 @@ func (handler *blockDataHandler) processAction(action *eos.Action, blockNum uint
 			toSend.Amount = asset(op.Quantity)
 			toSend.Memo = op.Memo
 
-			handler.sendHistory(toSend, op.To)
+			handler.sendHistory(users, toSend, pos, op.To)
 		// eosio
 		case *system.BuyRAM:
 			toSend.Type = proto.Action_BUY_RAM
@@ -114,43 +248,337 @@ func (handler *blockDataHandler) processAction(action *eos.Action, blockNum uint
 			toSend.To = string(op.Receiver)
 			toSend.Amount = asset(op.Quantity)
 
-			handler.sendHistory(toSend, op.Payer)
-			handler.sendHistory(toSend, op.Receiver)
+			handler.sendHistory(users, toSend, pos, op.Payer)
+			handler.sendHistory(users, toSend, pos, op.Receiver)
 		case *system.BuyRAMBytes:
 			toSend.Type = proto.Action_BUY_RAM_BYTES
 			toSend.From = string(op.Payer)
 			toSend.To = string(op.Receiver)
 			toSend.Amount = makeRAM(uint64(op.Bytes))
 
-			handler.sendHistory(toSend, op.Payer)
-			handler.sendHistory(toSend, op.Receiver)
+			handler.sendHistory(users, toSend, pos, op.Payer)
+			handler.sendHistory(users, toSend, pos, op.Receiver)
 		case *system.SellRAM:
 			toSend.From = string(op.Account)
 			toSend.To = string(op.Account) // you sell it for yourself
 			toSend.Amount = makeRAM(op.Bytes)
 
-			handler.sendHistory(toSend, op.Account)
+			handler.sendHistory(users, toSend, pos, op.Account)
+		case *system.DelegateBW:
+			toSend.Type = proto.Action_DELEGATE_BW
+			toSend.From = string(op.From)
+			toSend.To = string(op.Receiver)
+			toSend.Cpu = asset(op.StakeCPU)
+			toSend.Net = asset(op.StakeNet)
+			toSend.Amount = stakeSum(op.StakeCPU, op.StakeNet)
+			toSend.Transfer = bool(op.Transfer)
+
+			handler.sendHistory(users, toSend, pos, op.From)
+			handler.sendHistory(users, toSend, pos, op.Receiver)
+		case *system.UndelegateBW:
+			toSend.Type = proto.Action_UNDELEGATE_BW
+			toSend.From = string(op.From)
+			toSend.To = string(op.Receiver)
+			toSend.Cpu = asset(op.UnstakeCPU)
+			toSend.Net = asset(op.UnstakeNet)
+			toSend.Amount = stakeSum(op.UnstakeCPU, op.UnstakeNet)
+
+			handler.sendHistory(users, toSend, pos, op.From)
+			handler.sendHistory(users, toSend, pos, op.Receiver)
+		case *system.Refund:
+			// refunded amount is in eosio refunds table only
+			toSend.Type = proto.Action_REFUND
+			toSend.From = "eosio.stake" // staked tokens account
+			toSend.To = string(op.Owner)
+
+			handler.sendHistory(users, toSend, pos, op.Owner)
+		case *system.NewAccount:
+			toSend.Type = proto.Action_NEW_ACCOUNT
+			toSend.From = string(op.Creator)
+			toSend.To = string(op.Name)
+			toSend.Permissions = []*proto.Permission{
+				permission("owner", "", op.Owner),
+				permission("active", "owner", op.Active),
+			}
+
+			handler.sendHistory(users, toSend, pos, op.Creator)
+			handler.sendHistory(users, toSend, pos, op.Name)
+		case *system.UpdateAuth:
+			toSend.Type = proto.Action_UPDATE_AUTH
+			toSend.From = string(op.Account)
+			toSend.To = string(op.Account)
+			toSend.Permissions = []*proto.Permission{
+				permission(op.Permission, op.Parent, op.Auth),
+			}
+
+			handler.sendHistory(users, toSend, pos, op.Account)
+		case *system.DeleteAuth:
+			toSend.Type = proto.Action_DELETE_AUTH
+			toSend.From = string(op.Account)
+			toSend.To = string(op.Account)
+			toSend.Permissions = []*proto.Permission{
+				{Name: string(op.Permission)},
+			}
+
+			handler.sendHistory(users, toSend, pos, op.Account)
+		case *system.LinkAuth:
+			toSend.Type = proto.Action_LINK_AUTH
+			toSend.From = string(op.Account)
+			toSend.To = string(op.Account)
+			toSend.Link = &proto.PermissionLink{
+				Code:        string(op.Code),
+				Type:        string(op.Type),
+				Requirement: string(op.Requirement),
+			}
+
+			handler.sendHistory(users, toSend, pos, op.Account)
+		case *system.UnlinkAuth:
+			toSend.Type = proto.Action_UNLINK_AUTH
+			toSend.From = string(op.Account)
+			toSend.To = string(op.Account)
+			toSend.Link = &proto.PermissionLink{
+				Code: string(op.Code),
+				Type: string(op.Type),
+			}
+
+			handler.sendHistory(users, toSend, pos, op.Account)
+		case *system.VoteProducer:
+			toSend.Type = proto.Action_VOTE_PRODUCER
+			toSend.From = string(op.Voter)
+			toSend.To = string(op.Voter)
+			toSend.Proxy = string(op.Proxy)
+			for _, producer := range op.Producers {
+				toSend.Producers = append(toSend.Producers, string(producer))
+			}
+
+			handler.sendHistory(users, toSend, pos, op.Voter)
+		case *system.RegProxy:
+			toSend.Type = proto.Action_REG_PROXY
+			toSend.From = string(op.Proxy)
+			toSend.To = string(op.Proxy)
+			toSend.IsProxy = op.IsProxy
+
+			handler.sendHistory(users, toSend, pos, op.Proxy)
+		case *system.ClaimRewards:
+			// claimed amount is paid by inline transfers
+			toSend.Type = proto.Action_CLAIM_REWARDS
+			toSend.From = string(op.Owner)
+			toSend.To = string(op.Owner)
+
+			handler.sendHistory(users, toSend, pos, op.Owner)
+		// eosio.msig
+		case *msig.Propose:
+			proposal := &msigProposal{
+				requested: op.Requested,
+				trx:       op.Transaction,
+			}
+			handler.proposals.Add(op.Proposer, op.ProposalName, proposal)
+			toSend.Type = proto.Action_MSIG_PROPOSE
+			toSend.Proposal = &proto.Proposal{}
+
+			handler.sendProposal(users, toSend, pos, op.Proposer, op.ProposalName, op.Proposer, proposal)
+		case *msig.Approve:
+			toSend.Type = proto.Action_MSIG_APPROVE
+			toSend.Proposal = &proto.Proposal{Level: permissionLevel(op.Level)}
+			proposal := handler.proposal(users, op.Proposer, op.ProposalName, op.Level.Actor, true)
+
+			handler.sendProposal(users, toSend, pos, op.Proposer, op.ProposalName, op.Level.Actor, proposal)
+		case *msig.Unapprove:
+			toSend.Type = proto.Action_MSIG_UNAPPROVE
+			toSend.Proposal = &proto.Proposal{Level: permissionLevel(op.Level)}
+			proposal := handler.proposal(users, op.Proposer, op.ProposalName, op.Level.Actor, true)
+
+			handler.sendProposal(users, toSend, pos, op.Proposer, op.ProposalName, op.Level.Actor, proposal)
+		case *msig.Cancel:
+			// cancelled proposal is already erased from msig tables
+			toSend.Type = proto.Action_MSIG_CANCEL
+			toSend.Proposal = &proto.Proposal{}
+			proposal := handler.proposal(users, op.Proposer, op.ProposalName, op.Canceler, false)
+			handler.proposals.Remove(op.Proposer, op.ProposalName)
+
+			handler.sendProposal(users, toSend, pos, op.Proposer, op.ProposalName, op.Canceler, proposal)
+		case *msig.Exec:
+			// executed proposal is already erased from msig tables
+			toSend.Type = proto.Action_MSIG_EXEC
+			toSend.Proposal = &proto.Proposal{}
+			proposal := handler.proposal(users, op.Proposer, op.ProposalName, op.Executer, false)
+			handler.proposals.Remove(op.Proposer, op.ProposalName)
+
+			handler.sendProposal(users, toSend, pos, op.Proposer, op.ProposalName, op.Executer, proposal)
+		case *system.CancelDelay:
+			handler.cancelDeferred(users, toSend, pos, op)
+			handler.sendGeneric(users, toSend, pos, action)
+		default:
+			// transfer of other token contract
+			if action.Name == eos.ActN("transfer") && handler.tokens.Tracked(action.Account) {
+				transfer, err := decodeTransfer(action)
+				if err == nil {
+					handler.sendTransfer(users, toSend, pos, transfer)
+					return
+				}
+				log.Debugf("processAction: %s (block %d, %s)", err, pos.blockNum, handler.name)
+			}
+			handler.sendGeneric(users, toSend, pos, action)
 		}
 	}
 }
 
-// sendHistory checks if user is trackedUsers
-// and fills user data fields
-// and then sends extended action data to a chanel
-func (handler *blockDataHandler) sendHistory(action proto.Action, account eos.AccountName) {
-	if user, ok := handler.trackedUsers[string(account)]; ok {
-		log.Debugf("sendHistory:found action %s", account)
-		action.Resync = handler.resync
+// sendGeneric sends action decoded with contract ABI to tracked accounts
+// notified of it, authorizing it or named in its data.
+// ABI is fetched only if data may contain tracked account name
+func (handler *blockDataHandler) sendGeneric(users usersView, toSend proto.Action, pos *cursor, action *eos.Action) {
+	var accounts []eos.AccountName
+	seen := make(map[eos.AccountName]struct{})
+	addAccount := func(account eos.AccountName) {
+		if _, ok := seen[account]; ok {
+			return
+		}
+		seen[account] = struct{}{}
+		if _, ok := users.Get(string(account)); ok {
+			accounts = append(accounts, account)
+		}
+	}
+	if toSend.Receiver != "" {
+		addAccount(eos.AccountName(toSend.Receiver))
+	}
+	for _, auth := range action.Authorization {
+		addAccount(auth.Actor)
+		toSend.Authorization = append(toSend.Authorization, permissionLevel(auth))
+	}
+
+	var data interface{}
+	switch {
+	case len(action.HexData) != 0 && handler.abis != nil && (len(accounts) != 0 || namesTracked(users, action.HexData)):
+		var names []string
+		var err error
+		data, names, err = handler.decodeWithABI(action)
+		if err != nil {
+			log.Debugf("sendGeneric: %s (block %d, %s)", err, pos.blockNum, handler.name)
+			break
+		}
+		for _, name := range names {
+			addAccount(eos.AccountName(name))
+		}
+	case action.Data != nil && len(accounts) != 0:
+		// history api action or action registered in eos-go
+		data = action.Data
+	}
+	if len(accounts) == 0 {
+		return
+	}
+
+	toSend.Type = proto.Action_GENERIC
+	toSend.Name = string(action.Name)
+	toSend.To = string(action.Account)
+	if len(action.Authorization) != 0 {
+		toSend.From = string(action.Authorization[0].Actor)
+	}
+	if data != nil {
+		dataJSON, err := json.Marshal(data)
+		if err != nil {
+			log.Errorf("sendGeneric: %s::%s data: %s", action.Account, action.Name, err)
+		} else {
+			toSend.Data = string(dataJSON)
+		}
+	}
+	for _, account := range accounts {
+		handler.sendHistory(users, toSend, pos, account)
+	}
+}
+
+// decodeWithABI decodes action binary data with contract ABI,
+// values of name fields are returned as well
+func (handler *blockDataHandler) decodeWithABI(action *eos.Action) (interface{}, []string, error) {
+	if handler.abis == nil || len(action.HexData) == 0 {
+		return nil, nil, fmt.Errorf("%s::%s: no abi or binary data", action.Account, action.Name)
+	}
+	abi, err := handler.abis.Get(action.Account)
+	if err != nil {
+		return nil, nil, err
+	}
+	data, names, err := abi.DecodeAction(action.Name, action.HexData)
+	if err != nil {
+		return nil, nil, fmt.Errorf("%s: %s", action.Account, err)
+	}
+	return data, names, nil
+}
+
+// namesTracked checks if any 8 bytes of data are a tracked account name
+func namesTracked(users usersView, data []byte) bool {
+	for i := 0; i+8 <= len(data); i++ {
+		if _, ok := users.Get(eos.NameToString(binary.LittleEndian.Uint64(data[i:]))); ok {
+			return true
+		}
+	}
+	return false
+}
+
+// sendTransfer sends token transfer to sender and receiver.
+// Core token transfers by contracts other than eosio.token are skipped
+func (handler *blockDataHandler) sendTransfer(users usersView, action proto.Action, pos *cursor, op *token.Transfer) {
+	if handler.tokens.Counterfeit(eos.AccountName(action.Contract), op.Quantity.Symbol.Symbol) {
+		log.Debugf("sendTransfer: %s transfer of %s is skipped (%s)", action.Contract, op.Quantity.Symbol.Symbol, handler.name)
+		return
+	}
+	action.Type = proto.Action_TRANSFER_TOKEN
+	action.From = string(op.From)
+	action.To = string(op.To)
+	action.Amount = asset(op.Quantity)
+	action.Memo = op.Memo
 
+	handler.sendHistory(users, action, pos, op.From)
+	handler.sendHistory(users, action, pos, op.To)
+}
+
+// sendHistory checks if user is in users snapshot
+// and fills user data fields
+// and then queues extended action data
+// for every wallet tracking the account.
+// Queued actions are sent by flush
+func (handler *blockDataHandler) sendHistory(users usersView, action proto.Action, pos *cursor, account eos.AccountName) {
+	accountUsers, ok := users.Get(string(account))
+	if !ok {
+		return
+	}
+	log.Debugf("sendHistory:found action %s", account)
+	action.Resync = handler.resync
+	action.Address = string(account)
+	msgPos := *pos
+	msgPos.account = string(account)
+	// seq counts previous sendings to the account at this position
+	for _, queued := range handler.queued {
+		if queued.pos.samePosition(msgPos) && queued.pos.account == msgPos.account &&
+			queued.pos.wallet == accountUsers[0] {
+			msgPos.seq++
+		}
+	}
+	for _, user := range accountUsers {
 		action.UserID = user.UserID
 		action.WalletIndex = user.WalletIndex
 		action.AddressIndex = user.AddressIndex
-		action.Address = string(account)
+		msgPos.wallet = user
+		if !msgPos.unknown {
+			action.Cursor = msgPos.String()
+		}
+		handler.queued = append(handler.queued, queuedAction{pos: msgPos, action: action})
+	}
+}
+
+// flush sends queued actions in cursor order,
+// so stream resumed after any cursor misses nothing
+func (handler *blockDataHandler) flush() {
+	queued := handler.queued
+	handler.queued = handler.queued[:0]
+	sort.SliceStable(queued, func(i, j int) bool { return queued[i].pos.less(queued[j].pos) })
+	for _, q := range queued {
 		select {
 		case <-handler.ctx.Done():
 			return
-		case handler.history <- action:
-			return
+		case handler.history <- q.action:
+			atomic.AddUint64(&handler.actionsSent, 1)
+			if handler.delivered != nil {
+				handler.delivered[q.pos.blockNum] = append(handler.delivered[q.pos.blockNum], q.action)
+			}
 		}
 	}
 }
@@ -167,29 +595,36 @@ func makeRAM(bytes uint64) *proto.Asset {
 type blockHeightHandler struct {
 	ctx context.Context
 
+	// blockHeight keeps only the latest height
+	// so slow stream doesn't stall other subscribers
 	blockHeight chan proto.BlockHeight
 }
 
-func (handler blockHeightHandler) Handle(processable p2p.Message) {
+func (handler *blockHeightHandler) HandleBlock(block *eos.SignedBlock) {
 	select {
 	case <-handler.ctx.Done():
 		return
 	default:
-		name, ok := processable.Envelope.Type.Name()
-		log.Debugf("blockHeightHandler:Handle: %v %v ", name, ok)
-		if processable.Envelope.Type == eos.SignedBlockType {
-			block := processable.Envelope.P2PMessage.(*eos.SignedBlock)
-			id, err := block.BlockID()
-			if err != nil {
-				log.Errorf("blockHeightHandler:Handle:block_id: %s", err)
-				return
-			}
-			log.Debugf("blockHeightHandler:Handle handler send")
-			handler.blockHeight <- proto.BlockHeight{
-				HeadBlockNum: block.BlockNumber(),
-				HeadBlockId:  hex.EncodeToString(id),
-			}
-			log.Debugf("handler send done")
+	}
+	id, err := block.BlockID()
+	if err != nil {
+		log.Errorf("blockHeightHandler:HandleBlock:block_id: %s", err)
+		return
+	}
+	height := proto.BlockHeight{
+		HeadBlockNum: block.BlockNumber(),
+		HeadBlockId:  hex.EncodeToString(id),
+	}
+	for {
+		select {
+		case handler.blockHeight <- height:
+			return
+		default:
+		}
+		// drop outdated height
+		select {
+		case <-handler.blockHeight:
+		default:
 		}
 	}
 }
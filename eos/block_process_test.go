@@ -0,0 +1,418 @@
+/*
+ * Copyright 2018 Idealnaya rabota LLC
+ * Licensed under Multy.io license.
+ * See LICENSE for details
+ */
+
+package eos
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/Multy-io/Multy-EOS-node-service/proto"
+	"github.com/eoscanada/eos-go"
+	"github.com/eoscanada/eos-go/system"
+)
+
+// testMapping is an action mapping case
+type testMapping struct {
+	name   string
+	users  []string
+	action *eos.Action
+	// want are sent actions, Address is set for them by the test
+	want []proto.Action
+	// to are accounts got the action in the order of want
+	to []string
+}
+
+func (test *testMapping) run(t *testing.T) {
+	sent := mapActions(&blockDataHandler{}, testUsers(test.users...), test.action)
+	var want []proto.Action
+	for i, account := range test.to {
+		action := test.want[i]
+		action.Address = account
+		want = append(want, action)
+	}
+	if !reflect.DeepEqual(sent, want) {
+		t.Errorf("%s:\nsent %+v,\nwant %+v", test.name, sent, want)
+	}
+}
+
+func testAsset(amount int64, symbol string) eos.Asset {
+	return eos.Asset{Amount: amount, Symbol: eos.Symbol{Precision: 4, Symbol: symbol}}
+}
+
+func systemAction(name eos.ActionName, data interface{}) *eos.Action {
+	return &eos.Action{
+		Account:    "eosio",
+		Name:       name,
+		ActionData: eos.ActionData{Data: data},
+	}
+}
+
+func TestMapStakeActions(t *testing.T) {
+	delegated := proto.Action{
+		Type:     proto.Action_DELEGATE_BW,
+		Contract: "eosio",
+		From:     "alice",
+		To:       "bob",
+		Cpu:      &proto.Asset{Amount: 10000, Precision: 4, Symbol: "EOS"},
+		Net:      &proto.Asset{Amount: 5000, Precision: 4, Symbol: "EOS"},
+		Amount:   &proto.Asset{Amount: 15000, Precision: 4, Symbol: "EOS"},
+		Transfer: true,
+	}
+	delegateBW := &system.DelegateBW{
+		From:     "alice",
+		Receiver: "bob",
+		StakeCPU: testAsset(10000, "EOS"),
+		StakeNet: testAsset(5000, "EOS"),
+		Transfer: true,
+	}
+	mismatched := delegated
+	mismatched.Net = &proto.Asset{Amount: 5000, Precision: 4, Symbol: "SYS"}
+	mismatched.Amount = nil
+	undelegated := proto.Action{
+		Type:     proto.Action_UNDELEGATE_BW,
+		Contract: "eosio",
+		From:     "alice",
+		To:       "alice",
+		Cpu:      &proto.Asset{Amount: 10000, Precision: 4, Symbol: "EOS"},
+		Net:      &proto.Asset{Precision: 4, Symbol: "EOS"},
+		Amount:   &proto.Asset{Amount: 10000, Precision: 4, Symbol: "EOS"},
+	}
+
+	tests := []testMapping{
+		{
+			name:   "delegatebw to both",
+			users:  []string{"alice", "bob"},
+			action: systemAction("delegatebw", delegateBW),
+			want:   []proto.Action{delegated, delegated},
+			to:     []string{"alice", "bob"},
+		},
+		{
+			name:   "delegatebw to receiver",
+			users:  []string{"bob"},
+			action: systemAction("delegatebw", delegateBW),
+			want:   []proto.Action{delegated},
+			to:     []string{"bob"},
+		},
+		{
+			name:   "delegatebw untracked",
+			users:  []string{"carol"},
+			action: systemAction("delegatebw", delegateBW),
+		},
+		{
+			name:  "delegatebw with mismatched symbols",
+			users: []string{"alice"},
+			action: systemAction("delegatebw", &system.DelegateBW{
+				From:     "alice",
+				Receiver: "bob",
+				StakeCPU: testAsset(10000, "EOS"),
+				StakeNet: testAsset(5000, "SYS"),
+				Transfer: true,
+			}),
+			want: []proto.Action{mismatched},
+			to:   []string{"alice"},
+		},
+		{
+			name:  "undelegatebw to self",
+			users: []string{"alice"},
+			action: systemAction("undelegatebw", &system.UndelegateBW{
+				From:       "alice",
+				Receiver:   "alice",
+				UnstakeCPU: testAsset(10000, "EOS"),
+				UnstakeNet: testAsset(0, "EOS"),
+			}),
+			// both sides get it like both legs of self transfer
+			want: []proto.Action{undelegated, undelegated},
+			to:   []string{"alice", "alice"},
+		},
+		{
+			name:   "refund",
+			users:  []string{"alice"},
+			action: systemAction("refund", &system.Refund{Owner: "alice"}),
+			want: []proto.Action{{
+				Type:     proto.Action_REFUND,
+				Contract: "eosio",
+				From:     "eosio.stake",
+				To:       "alice",
+			}},
+			to: []string{"alice"},
+		},
+	}
+	for _, test := range tests {
+		test.run(t)
+	}
+}
+
+func TestStakeSum(t *testing.T) {
+	tests := []struct {
+		cpu, net eos.Asset
+		want     *proto.Asset
+	}{
+		{testAsset(1, "EOS"), testAsset(2, "EOS"), &proto.Asset{Amount: 3, Precision: 4, Symbol: "EOS"}},
+		{testAsset(1, "EOS"), testAsset(2, "SYS"), nil},
+		{testAsset(1, "EOS"), eos.Asset{Amount: 2, Symbol: eos.Symbol{Symbol: "EOS"}}, nil},
+	}
+	for _, test := range tests {
+		if got := stakeSum(test.cpu, test.net); !reflect.DeepEqual(got, test.want) {
+			t.Errorf("stakeSum(%+v, %+v) = %+v, want %+v", test.cpu, test.net, got, test.want)
+		}
+	}
+}
+
+func TestMapAuthActions(t *testing.T) {
+	auth := eos.Authority{
+		Threshold: 2,
+		Accounts: []eos.PermissionLevelWeight{
+			{Permission: eos.PermissionLevel{Actor: "bob", Permission: "active"}, Weight: 1},
+		},
+		Waits: []eos.WaitWeight{{WaitSec: 3600, Weight: 1}},
+	}
+	perm := func(name, parent string) *proto.Permission {
+		return &proto.Permission{
+			Name:      name,
+			Parent:    parent,
+			Threshold: 2,
+			Accounts:  []*proto.PermissionLevelWeight{{Actor: "bob", Permission: "active", Weight: 1}},
+			Waits:     []*proto.WaitWeight{{WaitSec: 3600, Weight: 1}},
+		}
+	}
+	created := proto.Action{
+		Type:        proto.Action_NEW_ACCOUNT,
+		Contract:    "eosio",
+		From:        "alice",
+		To:          "carol",
+		Permissions: []*proto.Permission{perm("owner", ""), perm("active", "owner")},
+	}
+	newAccount := &system.NewAccount{Creator: "alice", Name: "carol", Owner: auth, Active: auth}
+
+	tests := []testMapping{
+		{
+			name:   "newaccount to both",
+			users:  []string{"alice", "carol"},
+			action: systemAction("newaccount", newAccount),
+			want:   []proto.Action{created, created},
+			to:     []string{"alice", "carol"},
+		},
+		{
+			name:   "newaccount to created",
+			users:  []string{"carol"},
+			action: systemAction("newaccount", newAccount),
+			want:   []proto.Action{created},
+			to:     []string{"carol"},
+		},
+		{
+			name:  "updateauth",
+			users: []string{"alice"},
+			action: systemAction("updateauth", &system.UpdateAuth{
+				Account:    "alice",
+				Permission: "trade",
+				Parent:     "active",
+				Auth:       auth,
+			}),
+			want: []proto.Action{{
+				Type:        proto.Action_UPDATE_AUTH,
+				Contract:    "eosio",
+				From:        "alice",
+				To:          "alice",
+				Permissions: []*proto.Permission{perm("trade", "active")},
+			}},
+			to: []string{"alice"},
+		},
+		{
+			name:  "updateauth untracked",
+			users: []string{"bob"},
+			action: systemAction("updateauth", &system.UpdateAuth{
+				Account:    "alice",
+				Permission: "trade",
+				Parent:     "active",
+				Auth:       auth,
+			}),
+		},
+		{
+			name:  "deleteauth",
+			users: []string{"alice"},
+			action: systemAction("deleteauth", &system.DeleteAuth{
+				Account:    "alice",
+				Permission: "trade",
+			}),
+			want: []proto.Action{{
+				Type:        proto.Action_DELETE_AUTH,
+				Contract:    "eosio",
+				From:        "alice",
+				To:          "alice",
+				Permissions: []*proto.Permission{{Name: "trade"}},
+			}},
+			to: []string{"alice"},
+		},
+		{
+			name:  "linkauth",
+			users: []string{"alice"},
+			action: systemAction("linkauth", &system.LinkAuth{
+				Account:     "alice",
+				Code:        "eosio.token",
+				Type:        "transfer",
+				Requirement: "trade",
+			}),
+			want: []proto.Action{{
+				Type:     proto.Action_LINK_AUTH,
+				Contract: "eosio",
+				From:     "alice",
+				To:       "alice",
+				Link:     &proto.PermissionLink{Code: "eosio.token", Type: "transfer", Requirement: "trade"},
+			}},
+			to: []string{"alice"},
+		},
+		{
+			name:  "unlinkauth",
+			users: []string{"alice"},
+			action: systemAction("unlinkauth", &system.UnlinkAuth{
+				Account: "alice",
+				Code:    "eosio.token",
+				Type:    "transfer",
+			}),
+			want: []proto.Action{{
+				Type:     proto.Action_UNLINK_AUTH,
+				Contract: "eosio",
+				From:     "alice",
+				To:       "alice",
+				Link:     &proto.PermissionLink{Code: "eosio.token", Type: "transfer"},
+			}},
+			to: []string{"alice"},
+		},
+	}
+	for _, test := range tests {
+		test.run(t)
+	}
+}
+
+func TestMapGovernanceActions(t *testing.T) {
+	tests := []testMapping{
+		{
+			name:  "voteproducer",
+			users: []string{"alice", "bp1"},
+			action: systemAction("voteproducer", &system.VoteProducer{
+				Voter:     "alice",
+				Producers: []eos.AccountName{"bp1", "bp2"},
+			}),
+			// producers don't get votes
+			want: []proto.Action{{
+				Type:      proto.Action_VOTE_PRODUCER,
+				Contract:  "eosio",
+				From:      "alice",
+				To:        "alice",
+				Producers: []string{"bp1", "bp2"},
+			}},
+			to: []string{"alice"},
+		},
+		{
+			name:  "voteproducer by proxy",
+			users: []string{"alice"},
+			action: systemAction("voteproducer", &system.VoteProducer{
+				Voter: "alice",
+				Proxy: "proxy",
+			}),
+			want: []proto.Action{{
+				Type:     proto.Action_VOTE_PRODUCER,
+				Contract: "eosio",
+				From:     "alice",
+				To:       "alice",
+				Proxy:    "proxy",
+			}},
+			to: []string{"alice"},
+		},
+		{
+			name:  "regproxy",
+			users: []string{"proxy"},
+			action: systemAction("regproxy", &system.RegProxy{
+				Proxy:   "proxy",
+				IsProxy: true,
+			}),
+			want: []proto.Action{{
+				Type:     proto.Action_REG_PROXY,
+				Contract: "eosio",
+				From:     "proxy",
+				To:       "proxy",
+				IsProxy:  true,
+			}},
+			to: []string{"proxy"},
+		},
+		{
+			name:  "unregproxy",
+			users: []string{"proxy"},
+			action: systemAction("regproxy", &system.RegProxy{
+				Proxy: "proxy",
+			}),
+			want: []proto.Action{{
+				Type:     proto.Action_REG_PROXY,
+				Contract: "eosio",
+				From:     "proxy",
+				To:       "proxy",
+			}},
+			to: []string{"proxy"},
+		},
+		{
+			name:   "claimrewards",
+			users:  []string{"bp1"},
+			action: systemAction("claimrewards", &system.ClaimRewards{Owner: "bp1"}),
+			want: []proto.Action{{
+				Type:     proto.Action_CLAIM_REWARDS,
+				Contract: "eosio",
+				From:     "bp1",
+				To:       "bp1",
+			}},
+			to: []string{"bp1"},
+		},
+		{
+			name:   "claimrewards untracked",
+			users:  []string{"alice"},
+			action: systemAction("claimrewards", &system.ClaimRewards{Owner: "bp1"}),
+		},
+	}
+	for _, test := range tests {
+		test.run(t)
+	}
+}
+
+func TestReceiptAction(t *testing.T) {
+	tests := []struct {
+		status eos.TransactionStatus
+		want   proto.Action_ReceiptStatus
+	}{
+		{eos.TransactionStatusExecuted, proto.Action_EXECUTED},
+		{eos.TransactionStatusSoftFail, proto.Action_SOFT_FAIL},
+		{eos.TransactionStatusHardFail, proto.Action_HARD_FAIL},
+		{eos.TransactionStatusDelayed, proto.Action_DELAYED},
+		{eos.TransactionStatusExpired, proto.Action_EXPIRED},
+	}
+	for _, test := range tests {
+		tx := &eos.TransactionReceipt{}
+		tx.Status = test.status
+		tx.CPUUsageMicroSeconds = 250
+		tx.NetUsageWords = 16
+		tx.Transaction.ID = eos.SHA256Bytes{1, 2, 3}
+		action, err := receiptAction(tx, 7)
+		if err != nil {
+			t.Errorf("status %d: %s", test.status, err)
+			continue
+		}
+		want := proto.Action{
+			TransactionId: eos.SHA256Bytes{1, 2, 3},
+			BlockNum:      7,
+			ReceiptStatus: test.want,
+			CpuUsageUs:    250,
+			NetUsageWords: 16,
+		}
+		if !reflect.DeepEqual(action, want) {
+			t.Errorf("status %d: action %+v, want %+v", test.status, action, want)
+		}
+	}
+
+	tx := &eos.TransactionReceipt{}
+	tx.Status = eos.TransactionStatusUnknown
+	if _, err := receiptAction(tx, 7); err == nil {
+		t.Error("unknown status is accepted")
+	}
+}
@@ -0,0 +1,303 @@
+/*
+ * Copyright 2018 Idealnaya rabota LLC
+ * Licensed under Multy.io license.
+ * See LICENSE for details
+ */
+
+package eos
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/Multy-io/Multy-EOS-node-service/proto"
+)
+
+// lagReportInterval is an interval for logging lagging subscribers
+const lagReportInterval = time.Minute
+
+// SlowConsumerPolicy defines what to do with subscriber
+// which buffer is full
+type SlowConsumerPolicy string
+
+const (
+	// PolicyBlock waits for slow subscriber, so it slows down everyone
+	PolicyBlock SlowConsumerPolicy = "block"
+	// PolicyDropOldest drops the oldest action from subscriber's buffer
+	PolicyDropOldest SlowConsumerPolicy = "drop-oldest"
+	// PolicyDisconnect drops slow subscriber
+	PolicyDisconnect SlowConsumerPolicy = "disconnect"
+)
+
+// ParseSlowConsumerPolicy parses policy name, empty name means PolicyBlock
+func ParseSlowConsumerPolicy(name string) (SlowConsumerPolicy, error) {
+	switch policy := SlowConsumerPolicy(name); policy {
+	case "":
+		return PolicyBlock, nil
+	case PolicyBlock, PolicyDropOldest, PolicyDisconnect:
+		return policy, nil
+	}
+	return "", fmt.Errorf("unknown slow consumer policy: %s", name)
+}
+
+// subscription is a single subscriber's buffered actions stream
+type subscription struct {
+	name    string
+	actions chan proto.Action
+	// done is closed when subscription is cancelled
+	done      chan struct{}
+	closeOnce sync.Once
+	// disconnected is set if broadcaster dropped slow subscriber
+	disconnected int32
+
+	// metrics
+	queued  uint64
+	dropped uint64
+	maxLag  int64
+}
+
+// Actions gets subscriber's actions stream
+func (sub *subscription) Actions() <-chan proto.Action {
+	return sub.actions
+}
+
+// Done is closed when subscription is cancelled
+// by subscriber or by broadcaster
+func (sub *subscription) Done() <-chan struct{} {
+	return sub.done
+}
+
+// Disconnected reports if subscription was dropped as slow consumer
+func (sub *subscription) Disconnected() bool {
+	return atomic.LoadInt32(&sub.disconnected) == 1
+}
+
+// Lag gets number of actions waiting for subscriber
+func (sub *subscription) Lag() int {
+	return len(sub.actions)
+}
+
+func (sub *subscription) close() {
+	sub.closeOnce.Do(func() {
+		close(sub.done)
+	})
+}
+
+// updateMaxLag must be called after action is queued
+func (sub *subscription) updateMaxLag() {
+	lag := int64(sub.Lag())
+	for {
+		max := atomic.LoadInt64(&sub.maxLag)
+		if lag <= max || atomic.CompareAndSwapInt64(&sub.maxLag, max, lag) {
+			return
+		}
+	}
+}
+
+// Stats gets subscription counters
+func (sub *subscription) Stats() *proto.NewTxStream {
+	return &proto.NewTxStream{
+		Name:    sub.name,
+		Lag:     uint64(sub.Lag()),
+		MaxLag:  uint64(atomic.LoadInt64(&sub.maxLag)),
+		Queued:  atomic.LoadUint64(&sub.queued),
+		Dropped: atomic.LoadUint64(&sub.dropped),
+	}
+}
+
+// String formats subscription metrics
+func (sub *subscription) String() string {
+	return fmt.Sprintf("%s: lag %d (max %d), queued %d, dropped %d",
+		sub.name, sub.Lag(), atomic.LoadInt64(&sub.maxLag),
+		atomic.LoadUint64(&sub.queued), atomic.LoadUint64(&sub.dropped))
+}
+
+// broadcaster delivers every published action
+// to every subscriber's own buffer
+type broadcaster struct {
+	mu          sync.Mutex
+	subscribers map[*subscription]struct{}
+	bufferSize  int
+	policy      SlowConsumerPolicy
+	// backlog keeps the last actions published without subscribers
+	// for the first subscriber, e.g. resync results
+	backlog []proto.Action
+}
+
+// newBroadcaster makes broadcaster, bufferSize must be positive
+func newBroadcaster(bufferSize int, policy SlowConsumerPolicy) *broadcaster {
+	return &broadcaster{
+		subscribers: make(map[*subscription]struct{}),
+		bufferSize:  bufferSize,
+		policy:      policy,
+	}
+}
+
+// SetPolicy changes buffer size and policy for new subscriptions.
+// Buffer must hold at least one action as drop-oldest
+// and disconnect policies can't send to unbuffered stream
+func (b *broadcaster) SetPolicy(bufferSize int, policy SlowConsumerPolicy) error {
+	if bufferSize < 1 {
+		return fmt.Errorf("bad buffer size %d, it must be at least 1", bufferSize)
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.bufferSize = bufferSize
+	b.policy = policy
+	return nil
+}
+
+// Policy gets slow consumer policy
+func (b *broadcaster) Policy() SlowConsumerPolicy {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.policy
+}
+
+// Subscribe makes new subscription
+func (b *broadcaster) Subscribe(name string) *subscription {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	sub := &subscription{
+		name:    name,
+		actions: make(chan proto.Action, b.bufferSize),
+		done:    make(chan struct{}),
+	}
+	if len(b.backlog) > b.bufferSize {
+		b.backlog = b.backlog[len(b.backlog)-b.bufferSize:]
+	}
+	for _, action := range b.backlog {
+		sub.actions <- action
+		sub.queued++
+	}
+	b.backlog = nil
+	b.subscribers[sub] = struct{}{}
+	log.Infof("broadcaster: %s subscribed", name)
+	return sub
+}
+
+// Unsubscribe cancels subscription
+func (b *broadcaster) Unsubscribe(sub *subscription) {
+	b.mu.Lock()
+	delete(b.subscribers, sub)
+	b.mu.Unlock()
+	sub.close()
+	log.Infof("broadcaster: unsubscribed %s", sub)
+}
+
+// Stats gets counters of every subscription ordered by name
+func (b *broadcaster) Stats() []*proto.NewTxStream {
+	b.mu.Lock()
+	stats := make([]*proto.NewTxStream, 0, len(b.subscribers))
+	for sub := range b.subscribers {
+		stats = append(stats, sub.Stats())
+	}
+	b.mu.Unlock()
+	sort.Slice(stats, func(i, j int) bool { return stats[i].Name < stats[j].Name })
+	return stats
+}
+
+// Run publishes actions from in until ctx is done
+func (b *broadcaster) Run(ctx context.Context, in <-chan proto.Action) {
+	ticker := time.NewTicker(lagReportInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case action := <-in:
+			b.Publish(ctx, action)
+		case <-ticker.C:
+			b.reportLag()
+		}
+	}
+}
+
+// Publish sends action to all the subscribers
+// applying slow consumer policy. If there are no subscribers
+// action is kept for the first one
+func (b *broadcaster) Publish(ctx context.Context, action proto.Action) {
+	b.mu.Lock()
+	if len(b.subscribers) == 0 {
+		b.keep(action)
+		b.mu.Unlock()
+		return
+	}
+	policy := b.policy
+	subscribers := make([]*subscription, 0, len(b.subscribers))
+	for sub := range b.subscribers {
+		subscribers = append(subscribers, sub)
+	}
+	b.mu.Unlock()
+
+	for _, sub := range subscribers {
+		switch policy {
+		case PolicyDropOldest:
+			b.sendDropOldest(sub, action)
+		case PolicyDisconnect:
+			b.sendOrDisconnect(sub, action)
+		default:
+			select {
+			case sub.actions <- action:
+				atomic.AddUint64(&sub.queued, 1)
+			case <-sub.done:
+			case <-ctx.Done():
+				return
+			}
+		}
+		sub.updateMaxLag()
+	}
+}
+
+// keep adds action to backlog, the oldest action
+// is dropped if backlog is full. It must be called with mu locked
+func (b *broadcaster) keep(action proto.Action) {
+	if len(b.backlog) >= b.bufferSize {
+		log.Debugf("broadcaster: no subscribers, dropping action of block %d", b.backlog[0].BlockNum)
+		b.backlog = b.backlog[1:]
+	}
+	b.backlog = append(b.backlog, action)
+}
+
+func (b *broadcaster) sendDropOldest(sub *subscription, action proto.Action) {
+	for {
+		select {
+		case sub.actions <- action:
+			atomic.AddUint64(&sub.queued, 1)
+			return
+		default:
+		}
+		select {
+		case <-sub.actions:
+			atomic.AddUint64(&sub.dropped, 1)
+		default:
+		}
+	}
+}
+
+func (b *broadcaster) sendOrDisconnect(sub *subscription, action proto.Action) {
+	select {
+	case sub.actions <- action:
+		atomic.AddUint64(&sub.queued, 1)
+	default:
+		atomic.AddUint64(&sub.dropped, 1)
+		atomic.StoreInt32(&sub.disconnected, 1)
+		log.Warnf("broadcaster: disconnecting slow consumer %s", sub)
+		b.Unsubscribe(sub)
+	}
+}
+
+// reportLag logs metrics of subscribers that have pending actions
+func (b *broadcaster) reportLag() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	for sub := range b.subscribers {
+		if sub.Lag() > 0 || atomic.LoadUint64(&sub.dropped) > 0 {
+			log.Infof("broadcaster: %s", sub)
+		}
+	}
+}
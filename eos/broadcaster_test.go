@@ -0,0 +1,69 @@
+/*
+ * Copyright 2018 Idealnaya rabota LLC
+ * Licensed under Multy.io license.
+ * See LICENSE for details
+ */
+
+package eos
+
+import (
+	"context"
+	"testing"
+
+	"github.com/Multy-io/Multy-EOS-node-service/proto"
+)
+
+func TestBroadcasterRejectsUnbufferedStreams(t *testing.T) {
+	b := newBroadcaster(1, PolicyBlock)
+	for _, size := range []int{0, -1} {
+		if err := b.SetPolicy(size, PolicyDropOldest); err == nil {
+			t.Errorf("buffer size %d is accepted", size)
+		}
+	}
+	if err := b.SetPolicy(1, PolicyDropOldest); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestBroadcasterDropOldestStats(t *testing.T) {
+	b := newBroadcaster(2, PolicyDropOldest)
+	sub := b.Subscribe("slow")
+	for i := 0; i < 5; i++ {
+		b.Publish(context.Background(), proto.Action{BlockNum: uint32(i)})
+	}
+
+	stats := b.Stats()
+	if len(stats) != 1 {
+		t.Fatalf("%d streams, want 1", len(stats))
+	}
+	got := stats[0]
+	if got.Name != "slow" || got.Lag != 2 || got.MaxLag != 2 || got.Queued != 5 || got.Dropped != 3 {
+		t.Errorf("stats are %+v, want lag 2, max lag 2, queued 5, dropped 3", got)
+	}
+	if action := <-sub.Actions(); action.BlockNum != 3 {
+		t.Errorf("oldest kept action is of block %d, want 3", action.BlockNum)
+	}
+
+	b.Unsubscribe(sub)
+	if len(b.Stats()) != 0 {
+		t.Error("unsubscribed stream has stats")
+	}
+}
+
+func TestBroadcasterKeepsActionsForFirstSubscriber(t *testing.T) {
+	b := newBroadcaster(2, PolicyBlock)
+	for i := 0; i < 3; i++ {
+		b.Publish(context.Background(), proto.Action{BlockNum: uint32(i)})
+	}
+
+	first := b.Subscribe("first")
+	second := b.Subscribe("second")
+	if first.Lag() != 2 || second.Lag() != 0 {
+		t.Fatalf("first stream got %d actions, second %d, want 2 and 0", first.Lag(), second.Lag())
+	}
+	for _, want := range []uint32{1, 2} {
+		if action := <-first.Actions(); action.BlockNum != want {
+			t.Errorf("action of block %d is kept, want %d", action.BlockNum, want)
+		}
+	}
+}
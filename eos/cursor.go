@@ -0,0 +1,114 @@
+/*
+ * Copyright 2018 Idealnaya rabota LLC
+ * Licensed under Multy.io license.
+ * See LICENSE for details
+ */
+
+package eos
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// cursor is a position of sent action in chain.
+// It depends on chain and the receiving account and wallet only,
+// so tracking and untracking other wallets doesn't change it
+type cursor struct {
+	blockNum    uint32
+	txIndex     uint32
+	actionIndex uint32
+	// account is an account the action is sent to
+	account string
+	// seq is a number of the action sending to account,
+	// as account may get action several times, e.g. self transfer
+	seq uint32
+	// wallet is a wallet tracking account
+	wallet UserData
+	// unknown is set if position in block is unknown,
+	// e.g. for history api actions, such cursor isn't sent
+	unknown bool
+}
+
+// String formats cursor as "block:tx:action:account:seq:wallet:address:user"
+func (c cursor) String() string {
+	return fmt.Sprintf("%d:%d:%d:%s:%d:%d:%d:%s", c.blockNum, c.txIndex, c.actionIndex,
+		c.account, c.seq, c.wallet.WalletIndex, c.wallet.AddressIndex, c.wallet.UserID)
+}
+
+// less reports if c goes before other in chain
+func (c cursor) less(other cursor) bool {
+	if c.blockNum != other.blockNum {
+		return c.blockNum < other.blockNum
+	}
+	if c.txIndex != other.txIndex {
+		return c.txIndex < other.txIndex
+	}
+	if c.actionIndex != other.actionIndex {
+		return c.actionIndex < other.actionIndex
+	}
+	if c.account != other.account {
+		return c.account < other.account
+	}
+	if c.seq != other.seq {
+		return c.seq < other.seq
+	}
+	return userLess(c.wallet, other.wallet)
+}
+
+// samePosition reports if c and other are the same action position
+func (c cursor) samePosition(other cursor) bool {
+	return c.blockNum == other.blockNum && c.txIndex == other.txIndex && c.actionIndex == other.actionIndex
+}
+
+// userLess orders wallets
+func userLess(a, b UserData) bool {
+	if a.UserID != b.UserID {
+		return a.UserID < b.UserID
+	}
+	if a.WalletIndex != b.WalletIndex {
+		return a.WalletIndex < b.WalletIndex
+	}
+	return a.AddressIndex < b.AddressIndex
+}
+
+// parseCursor parses cursor, user id may contain colons so it goes last
+func parseCursor(s string) (cursor, error) {
+	parts := strings.SplitN(s, ":", 8)
+	if len(parts) != 8 {
+		return cursor{}, fmt.Errorf("bad cursor: %s", s)
+	}
+	var nums [3]uint32
+	for i := range nums {
+		num, err := strconv.ParseUint(parts[i], 10, 32)
+		if err != nil {
+			return cursor{}, fmt.Errorf("bad cursor %s: %s", s, err)
+		}
+		nums[i] = uint32(num)
+	}
+	seq, err := strconv.ParseUint(parts[4], 10, 32)
+	if err != nil {
+		return cursor{}, fmt.Errorf("bad cursor %s: %s", s, err)
+	}
+	var indexes [2]int32
+	for i := range indexes {
+		index, err := strconv.ParseInt(parts[5+i], 10, 32)
+		if err != nil {
+			return cursor{}, fmt.Errorf("bad cursor %s: %s", s, err)
+		}
+		indexes[i] = int32(index)
+	}
+	return cursor{
+		blockNum:    nums[0],
+		txIndex:     nums[1],
+		actionIndex: nums[2],
+		account:     parts[3],
+		seq:         uint32(seq),
+		wallet: UserData{
+			UserID:       parts[7],
+			WalletIndex:  indexes[0],
+			AddressIndex: indexes[1],
+		},
+	}, nil
+}
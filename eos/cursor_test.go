@@ -0,0 +1,131 @@
+/*
+ * Copyright 2018 Idealnaya rabota LLC
+ * Licensed under Multy.io license.
+ * See LICENSE for details
+ */
+
+package eos
+
+import (
+	"context"
+	"testing"
+
+	"github.com/Multy-io/Multy-EOS-node-service/proto"
+	"github.com/eoscanada/eos-go"
+	"github.com/eoscanada/eos-go/token"
+)
+
+func TestCursorParse(t *testing.T) {
+	pos := cursor{
+		blockNum:    10,
+		txIndex:     2,
+		actionIndex: 3,
+		account:     "alice",
+		seq:         1,
+		wallet:      UserData{UserID: "user:with:colons", WalletIndex: 4, AddressIndex: -1},
+	}
+	parsed, err := parseCursor(pos.String())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if parsed != pos {
+		t.Errorf("parsed %+v, want %+v", parsed, pos)
+	}
+
+	for _, bad := range []string{"", "1:2:3", "10:2:3:5", "1:2:3:alice:x:0:0:u", "a:2:3:alice:0:0:0:u"} {
+		if _, err := parseCursor(bad); err == nil {
+			t.Errorf("cursor %q is parsed", bad)
+		}
+	}
+}
+
+// sentCursors processes self transfer of alice and gets sent cursors
+func sentCursors(t *testing.T, users map[string][]UserData) []string {
+	history := make(chan proto.Action, 16)
+	handler := &blockDataHandler{
+		ctx:          context.Background(),
+		history:      history,
+		trackedUsers: newTrackedUsers(users),
+	}
+	transfer := &eos.Action{
+		Account: "eosio.token",
+		Name:    "transfer",
+		ActionData: eos.ActionData{
+			Data: &token.Transfer{From: "alice", To: "alice"},
+		},
+	}
+	pos := cursor{blockNum: 7, actionIndex: 1}
+	handler.mapAction(handler.trackedUsers.Snapshot(), transfer, proto.Action{BlockNum: 7, ActionIndex: 1}, &pos)
+	handler.flush()
+	close(history)
+
+	var cursors []string
+	var last *cursor
+	for action := range history {
+		pos, err := parseCursor(action.Cursor)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if last != nil && !last.less(pos) {
+			t.Errorf("%s is sent after %s", action.Cursor, last)
+		}
+		last = &pos
+		cursors = append(cursors, action.Cursor)
+	}
+	return cursors
+}
+
+func TestCursorDoesNotDependOnOtherWallets(t *testing.T) {
+	wallet := UserData{UserID: "b", WalletIndex: 1}
+	other := UserData{UserID: "a", WalletIndex: 2}
+
+	alone := sentCursors(t, map[string][]UserData{"alice": {wallet}})
+	if len(alone) != 2 {
+		t.Fatalf("%d actions sent for self transfer, want 2", len(alone))
+	}
+	if alone[0] == alone[1] {
+		t.Errorf("self transfer actions have the same cursor %s", alone[0])
+	}
+
+	shared := sentCursors(t, map[string][]UserData{"alice": {wallet, other}, "bob": {other}})
+	found := 0
+	for _, c := range shared {
+		for _, a := range alone {
+			if c == a {
+				found++
+			}
+		}
+	}
+	if found != len(alone) {
+		t.Errorf("wallet cursors %v are changed by other wallets: %v", alone, shared)
+	}
+}
+
+func TestHistoryAPIActionHasNoCursor(t *testing.T) {
+	history := make(chan proto.Action, 16)
+	handler := &blockDataHandler{
+		ctx:          context.Background(),
+		history:      history,
+		trackedUsers: newTrackedUsers(map[string][]UserData{"alice": {{UserID: "a"}}}),
+	}
+	transfer := &eos.Action{
+		Account: "eosio.token",
+		Name:    "transfer",
+		ActionData: eos.ActionData{
+			Data: &token.Transfer{From: "alice", To: "bob"},
+		},
+	}
+	handler.processAction(handler.trackedUsers.Snapshot(), transfer, cursor{blockNum: 7}, nil)
+	close(history)
+
+	sent := 0
+	for action := range history {
+		sent++
+		if action.Cursor != "" {
+			t.Errorf("action of unknown position has cursor %s", action.Cursor)
+		}
+	}
+	if sent != 1 {
+		t.Errorf("%d actions sent, want 1", sent)
+	}
+}
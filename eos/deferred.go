@@ -0,0 +1,177 @@
+/*
+ * Copyright 2018 Idealnaya rabota LLC
+ * Licensed under Multy.io license.
+ * See LICENSE for details
+ */
+
+package eos
+
+import (
+	"encoding/hex"
+	"time"
+
+	"github.com/Multy-io/Multy-EOS-node-service/proto"
+	"github.com/eoscanada/eos-go"
+	"github.com/eoscanada/eos-go/system"
+)
+
+const (
+	// deferredExpirationWindow is a time delayed transaction is kept
+	// after its delay, node executes or expires it before
+	deferredExpirationWindow = time.Hour
+	// maxDeferredTxs limits number of kept delayed transactions,
+	// the one expiring first is dropped on overflow
+	maxDeferredTxs = 10000
+)
+
+// scheduledTx is a delayed transaction waiting for execution
+type scheduledTx struct {
+	actions []*eos.Action
+	// contextFree is a number of context free actions
+	// going before actions in action indexes
+	contextFree int
+	expires     time.Time
+}
+
+// deferredTxs keeps delayed transactions until they are executed,
+// cancelled or expired. It belongs to single block handler,
+// so it isn't concurrency-safe
+type deferredTxs struct {
+	txs map[string]*scheduledTx
+}
+
+func newDeferredTxs() *deferredTxs {
+	return &deferredTxs{
+		txs: make(map[string]*scheduledTx),
+	}
+}
+
+// Schedule keeps transaction's actions, transaction of orphaned block
+// is replaced when it's included again
+func (deferred *deferredTxs) Schedule(id eos.SHA256Bytes, tx *scheduledTx) {
+	if deferred == nil {
+		return
+	}
+	key := hex.EncodeToString(id)
+	if _, ok := deferred.txs[key]; !ok && len(deferred.txs) >= maxDeferredTxs {
+		deferred.dropFirstExpiring()
+	}
+	deferred.txs[key] = tx
+}
+
+func (deferred *deferredTxs) dropFirstExpiring() {
+	var first string
+	var expires time.Time
+	for key, tx := range deferred.txs {
+		if first == "" || tx.expires.Before(expires) {
+			first, expires = key, tx.expires
+		}
+	}
+	log.Warnf("deferred transactions limit %d is reached, %s is dropped", maxDeferredTxs, first)
+	delete(deferred.txs, first)
+}
+
+// Take gets and forgets scheduled transaction, nil if it's unknown
+func (deferred *deferredTxs) Take(id eos.SHA256Bytes) *scheduledTx {
+	if deferred == nil {
+		return nil
+	}
+	key := hex.EncodeToString(id)
+	tx := deferred.txs[key]
+	delete(deferred.txs, key)
+	return tx
+}
+
+// Prune forgets transactions expired before block time
+func (deferred *deferredTxs) Prune(blockTime time.Time) {
+	if deferred == nil {
+		return
+	}
+	for key, tx := range deferred.txs {
+		if tx.expires.Before(blockTime) {
+			delete(deferred.txs, key)
+		}
+	}
+}
+
+// scheduleDeferred keeps delayed transaction involving tracked accounts
+// to send its actions when it's executed or cancelled
+func (handler *blockDataHandler) scheduleDeferred(users usersSnapshot, id eos.SHA256Bytes, unpacked *eos.SignedTransaction, block *eos.SignedBlock) {
+	if !actionsInvolve(users, unpacked.Actions) {
+		return
+	}
+	delay := time.Duration(unpacked.DelaySec) * time.Second
+	handler.deferred.Schedule(id, &scheduledTx{
+		actions:     unpacked.Actions,
+		contextFree: len(unpacked.ContextFreeActions),
+		expires:     block.Timestamp.Time.Add(delay + deferredExpirationWindow),
+	})
+}
+
+// actionsInvolve checks if tracked account is a contract or an actor
+// of any action or may be named in action data
+func actionsInvolve(users usersSnapshot, actions []*eos.Action) bool {
+	for _, action := range actions {
+		if _, ok := users.Get(string(action.Account)); ok {
+			return true
+		}
+		for _, auth := range action.Authorization {
+			if _, ok := users.Get(string(auth.Actor)); ok {
+				return true
+			}
+		}
+		if namesTracked(users, action.HexData) {
+			return true
+		}
+	}
+	return false
+}
+
+// processDeferred sends actions of executed, failed or expired
+// deferred transaction. Transaction scheduled by contract
+// is known from traces only, traces are nil if they aren't got
+func (handler *blockDataHandler) processDeferred(users usersSnapshot, tx *eos.TransactionReceipt, txIndex uint32, receipt proto.Action, traces []actionTrace) {
+	scheduled := handler.deferred.Take(tx.Transaction.ID)
+	if handler.executedOnly && tx.Status != eos.TransactionStatusExecuted {
+		return
+	}
+	receipt.DeferredStatus = proto.Action_DEFERRED_EXECUTED
+	if traces != nil {
+		contextFree := 0
+		if scheduled != nil {
+			contextFree = scheduled.contextFree
+		}
+		handler.processTraces(users, traces, contextFree, txIndex, receipt)
+		return
+	}
+	if scheduled == nil {
+		log.Debugf("deferred transaction %x is unknown (block %d, %s)", tx.Transaction.ID, receipt.BlockNum, handler.name)
+		return
+	}
+	for idx, action := range scheduled.actions {
+		pos := cursor{
+			blockNum:    receipt.BlockNum,
+			txIndex:     txIndex,
+			actionIndex: uint32(scheduled.contextFree + idx),
+		}
+		toSend := receipt
+		toSend.ActionIndex = int64(scheduled.contextFree + idx)
+		handler.mapAction(users, action, toSend, &pos)
+		handler.flush()
+	}
+}
+
+// cancelDeferred sends actions of delayed transaction cancelled
+// by canceldelay at pos
+func (handler *blockDataHandler) cancelDeferred(users usersView, toSend proto.Action, pos *cursor, op *system.CancelDelay) {
+	scheduled := handler.deferred.Take(op.TransactionID)
+	if scheduled == nil {
+		return
+	}
+	toSend.TransactionId = op.TransactionID
+	toSend.DeferredStatus = proto.Action_DEFERRED_CANCELLED
+	for idx, action := range scheduled.actions {
+		toSend.ActionIndex = int64(scheduled.contextFree + idx)
+		handler.mapAction(users, action, toSend, pos)
+	}
+}
@@ -0,0 +1,52 @@
+/*
+ * Copyright 2018 Idealnaya rabota LLC
+ * Licensed under Multy.io license.
+ * See LICENSE for details
+ */
+
+package eos
+
+import (
+	"encoding/binary"
+	"testing"
+	"time"
+
+	"github.com/eoscanada/eos-go"
+)
+
+func TestDeferredTxsLimit(t *testing.T) {
+	deferred := newDeferredTxs()
+	start := time.Now()
+	for i := 0; i < maxDeferredTxs+1; i++ {
+		id := make(eos.SHA256Bytes, 4)
+		binary.BigEndian.PutUint32(id, uint32(i))
+		deferred.Schedule(id, &scheduledTx{expires: start.Add(time.Duration(i) * time.Second)})
+	}
+	if len(deferred.txs) != maxDeferredTxs {
+		t.Fatalf("%d transactions kept, want %d", len(deferred.txs), maxDeferredTxs)
+	}
+	if deferred.Take(eos.SHA256Bytes{0, 0, 0, 0}) != nil {
+		t.Error("transaction expiring first is kept")
+	}
+	if deferred.Take(eos.SHA256Bytes{0, 0, 0, 1}) == nil {
+		t.Error("transaction expiring second is dropped")
+	}
+}
+
+func TestActionsInvolve(t *testing.T) {
+	users := usersSnapshot{"alice": {{UserID: "a"}}}
+	cases := []struct {
+		name   string
+		action *eos.Action
+		want   bool
+	}{
+		{"contract", &eos.Action{Account: "alice"}, true},
+		{"actor", &eos.Action{Account: "eosio.token", Authorization: []eos.PermissionLevel{{Actor: "alice"}}}, true},
+		{"other", &eos.Action{Account: "eosio.token", Authorization: []eos.PermissionLevel{{Actor: "bob"}}}, false},
+	}
+	for _, c := range cases {
+		if got := actionsInvolve(users, []*eos.Action{c.action}); got != c.want {
+			t.Errorf("%s: involve is %v, want %v", c.name, got, c.want)
+		}
+	}
+}
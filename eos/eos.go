@@ -17,8 +17,8 @@ import (
 	"github.com/Multy-io/Multy-EOS-node-service/proto"
 	"github.com/eoscanada/eos-go"
 	"github.com/eoscanada/eos-go/ecc"
-	"github.com/eoscanada/eos-go/p2p"
 	"github.com/eoscanada/eos-go/system"
+	"google.golang.org/grpc/peer"
 	// blank import for registering token actions.
 	"bytes"
 	"io/ioutil"
@@ -32,10 +32,13 @@ import (
 
 const (
 	// historyBufferSize is a size for users' history streaming
+	// and default size of every NewTx subscriber's buffer
 	historyBufferSize = 100
-	// resyncTimeout is a timeout for account resync operation.
+	// resyncTimeout is a timeout for resync blocks scan.
 	// this is need to stop goroutines if something go wrong
 	resyncTimeout = time.Hour * 12
+	// rpcRequestTimeout limits raw node requests
+	rpcRequestTimeout = 10 * time.Second
 )
 
 // UserData is a multy wallet user data
@@ -50,33 +53,198 @@ type Server struct {
 	api     *eos.API
 	p2pAddr string
 	rpcAddr string
+	// rpcClient makes node requests eos-go has no methods for
+	rpcClient *http.Client
 
 	account   eos.AccountName
 	activeKey string
 
 	version proto.ServiceVersion
 
-	startBlockNum uint32 // TODO: pass this with requests
+	// ctx is cancelled when server is closed
+	ctx    context.Context
+	cancel context.CancelFunc
 
-	// accounts to track
-	trackedUsers map[string]UserData
-	// user history chan
+	// accounts to track, shared with NewTx handlers
+	trackedUsers *trackedUsers
+	// user history chan, all the block handlers write here
 	historyCh chan proto.Action
+	// broadcaster delivers historyCh to every NewTx stream
+	broadcaster *broadcaster
+
+	// ingestion is single p2p connection for all the streams
+	ingestion *blockIngestion
+	// live block handler is single for all NewTx streams
+	liveHandler *blockDataHandler
+	// lib keeps last irreversible block for actions' status
+	lib *libTracker
+	// resyncJobs are running and recently finished resyncs
+	resyncJobs *resyncJobs
+	// resyncScheduler runs resync jobs
+	resyncScheduler *resyncScheduler
+	// actionIndex keeps delivered actions, may be nil
+	actionIndex ActionIndex
+	// tokens are token contracts tracked besides eosio.token
+	tokens *tokenContracts
+	// abis are contracts ABIs for generic actions decoding
+	abis *abiCache
+	// msigTable gets msig proposals for block handlers
+	msigTable *proposalTable
+	// traces is a source of inline actions, may be nil
+	traces traceSource
+	// executedOnly skips not executed transactions
+	executedOnly bool
 }
 
-// NewServer constructs new server.
+// NewServer constructs new server
+// and loads tracked users from storage.
 // For proper usage you need to set version and signed
-// using SetVersion & SetSigner
-func NewServer(rpcAddr, p2pAddr string) *Server {
+// using SetVersion & SetSigner and then Start it
+func NewServer(rpcAddr, p2pAddr string, storage UsersStorage) (*Server, error) {
+	trackedUsers, err := loadTrackedUsers(storage)
+	if err != nil {
+		return nil, fmt.Errorf("load tracked users: %s", err)
+	}
+	log.Infof("loaded %d tracked users", trackedUsers.Len())
+	api := eos.New(rpcAddr)
+	ctx, cancel := context.WithCancel(context.Background())
 	server := &Server{
-		api:           eos.New(rpcAddr),
-		p2pAddr:       p2pAddr,
-		rpcAddr:       rpcAddr,
-		trackedUsers:  make(map[string]UserData),
-		startBlockNum: 0, // 0 for most recent by default
-		historyCh:     make(chan proto.Action, historyBufferSize),
+		api:          api,
+		p2pAddr:      p2pAddr,
+		rpcAddr:      rpcAddr,
+		rpcClient:    &http.Client{Timeout: rpcRequestTimeout},
+		ctx:          ctx,
+		cancel:       cancel,
+		trackedUsers: trackedUsers,
+		historyCh:    make(chan proto.Action, historyBufferSize),
+		broadcaster:  newBroadcaster(historyBufferSize, PolicyBlock),
+		lib:          newLIBTracker(api),
+		resyncJobs:   newResyncJobs(),
+		tokens:       newTokenContracts(),
+		abis:         newABICache(rpcAddr),
+		msigTable:    newProposalTable(rpcAddr),
+	}
+	server.ingestion = newBlockIngestion(api, p2pAddr, server.lib)
+	server.resyncScheduler = newResyncScheduler(server.ingestion, newHistoryAPI(rpcAddr), server.resyncJobs)
+	return server, nil
+}
+
+// newBlockHandler makes handler sending actions of users to history
+// with server's decoding settings
+func (server *Server) newBlockHandler(ctx context.Context, name string, users *trackedUsers, history chan proto.Action) *blockDataHandler {
+	return &blockDataHandler{
+		ctx:          ctx,
+		name:         name,
+		trackedUsers: users,
+		tokens:       server.tokens,
+		abis:         server.abis,
+		traces:       server.traces,
+		executedOnly: server.executedOnly,
+		deferred:     newDeferredTxs(),
+		proposals:    newMsigProposals(),
+		msigTable:    server.msigTable,
+		history:      history,
+	}
+}
+
+// SetActionIndex sets local index of delivered actions,
+// it must be called before Start
+func (server *Server) SetActionIndex(index ActionIndex) {
+	server.actionIndex = index
+}
+
+// Start starts live blocks ingestion from the head block,
+// irreversible block tracking and resync workers
+func (server *Server) Start() {
+	var actions <-chan proto.Action = server.historyCh
+	if server.actionIndex != nil {
+		actions = server.indexActions(actions)
+	}
+	go server.broadcaster.Run(server.ctx, actions)
+	go server.lib.Run(server.ctx)
+	go server.resyncScheduler.Run(server.ctx)
+	server.liveHandler = server.newBlockHandler(server.ctx, "NewTx", server.trackedUsers, server.historyCh)
+	server.liveHandler.delivered = make(map[uint32][]proto.Action)
+	server.ingestion.Subscribe(server.liveHandler)
+	server.ingestion.Start(0)
+}
+
+// indexActions stores actions in index and passes them further
+func (server *Server) indexActions(in <-chan proto.Action) <-chan proto.Action {
+	out := make(chan proto.Action)
+	go func() {
+		for {
+			select {
+			case <-server.ctx.Done():
+				return
+			case action := <-in:
+				server.actionIndex.Put(action)
+				select {
+				case <-server.ctx.Done():
+					return
+				case out <- action:
+				}
+			}
+		}
+	}()
+	return out
+}
+
+// Close stops all the streams and blocks ingestion.
+// Streams are cancelled first to unblock handlers sending actions,
+// otherwise ingestion may wait for them forever
+func (server *Server) Close() {
+	server.cancel()
+	server.ingestion.Stop()
+}
+
+// SetNewTxPolicy sets buffer size of every NewTx stream
+// and what to do when stream's buffer is full.
+// Buffer size must be positive
+func (server *Server) SetNewTxPolicy(bufferSize int, policy SlowConsumerPolicy) error {
+	return server.broadcaster.SetPolicy(bufferSize, policy)
+}
+
+// SetResyncLimits sets number of concurrent resync block scans
+// and max number of jobs in one scan
+func (server *Server) SetResyncLimits(workers, batchSize int) {
+	server.resyncScheduler.SetLimits(workers, batchSize)
+}
+
+// SetBlockArchive sets local blocks archive for replays,
+// it must be called before Start
+func (server *Server) SetBlockArchive(archive BlockArchive) {
+	server.ingestion.SetArchive(archive)
+}
+
+// SetTokenContracts sets token contracts which transfers are tracked
+// besides eosio.token ones: empty allow list is for all the contracts
+// except denied ones. It must be called before Start
+func (server *Server) SetTokenContracts(allow, deny []string) {
+	server.tokens.Set(allow, deny)
+}
+
+// SetCoreSymbol sets symbol of the chain's core token, empty for EOS.
+// Its transfers by contracts other than eosio.token are skipped.
+// It must be called before Start
+func (server *Server) SetCoreSymbol(symbol string) {
+	server.tokens.SetCoreSymbol(symbol)
+}
+
+// SetActionTraces enables inline actions and notifications processing.
+// Traces of block's executed transactions are requested
+// from history api concurrently, it must be called before Start
+func (server *Server) SetActionTraces(enabled bool) {
+	server.traces = nil
+	if enabled {
+		server.traces = newHistoryTraces(server.rpcAddr)
 	}
-	return server
+}
+
+// SetExecutedOnly sets if actions of failed, delayed
+// and expired transactions are skipped, it must be called before Start
+func (server *Server) SetExecutedOnly(executedOnly bool) {
+	server.executedOnly = executedOnly
 }
 
 // SetVersion sets version info for multy-back to request
@@ -108,23 +276,73 @@ func (server *Server) ServiceInfo(_ context.Context, _ *proto.Empty) (*proto.Ser
 }
 
 func (server *Server) InitialAdd(_ context.Context, userData *proto.UsersData) (*proto.ReplyInfo, error) {
-	for key, val := range userData.GetMap() {
-		// TODO: check if account exist?
-		server.trackedUsers[key] = UserData{
-			AddressIndex: val.AddressIndex,
-			UserID:       val.UserID,
-			WalletIndex:  val.WalletIndex,
-		}
+	err := server.trackedUsers.Add(usersData(userData))
+	if err != nil {
+		err = fmt.Errorf("save users: %s", err)
+		return &proto.ReplyInfo{
+			Message: err.Error(),
+		}, err
 	}
 	return &proto.ReplyInfo{}, nil
 }
 
 func (server *Server) AddNewAddress(_ context.Context, acc *proto.WatchAddress) (*proto.ReplyInfo, error) {
 	// TODO: check if account exist?
-	server.trackedUsers[acc.Address] = UserData{
-		WalletIndex:  acc.WalletIndex,
-		UserID:       acc.UserID,
-		AddressIndex: acc.AddressIndex,
+	err := server.trackedUsers.Add(map[string][]UserData{
+		acc.Address: {watchAddressUser(acc)},
+	})
+	if err != nil {
+		err = fmt.Errorf("save user: %s", err)
+		return &proto.ReplyInfo{
+			Message: err.Error(),
+		}, err
+	}
+	return &proto.ReplyInfo{}, nil
+}
+
+// RemoveAddress stops tracking of address by wallet.
+// If userID is empty address is untracked for all the wallets
+func (server *Server) RemoveAddress(_ context.Context, acc *proto.WatchAddress) (*proto.ReplyInfo, error) {
+	if _, ok := server.trackedUsers.Get(acc.Address); !ok {
+		err := fmt.Errorf("user not trackedUsers: %s", acc.Address)
+		return &proto.ReplyInfo{
+			Message: err.Error(),
+		}, err
+	}
+	var err error
+	if acc.UserID == "" {
+		err = server.trackedUsers.RemoveAccount(acc.Address)
+	} else {
+		err = server.trackedUsers.RemoveWallet(acc.Address, watchAddressUser(acc))
+	}
+	if err != nil {
+		err = fmt.Errorf("remove user: %s", err)
+		return &proto.ReplyInfo{
+			Message: err.Error(),
+		}, err
+	}
+	return &proto.ReplyInfo{}, nil
+}
+
+func (server *Server) RemoveUser(_ context.Context, user *proto.UserID) (*proto.ReplyInfo, error) {
+	accounts, err := server.trackedUsers.RemoveUser(user.UserID)
+	if err != nil {
+		err = fmt.Errorf("remove user: %s", err)
+		return &proto.ReplyInfo{
+			Message: err.Error(),
+		}, err
+	}
+	log.Debugf("RemoveUser: %s removed %v", user.UserID, accounts)
+	return &proto.ReplyInfo{}, nil
+}
+
+func (server *Server) ReplaceUsersData(_ context.Context, userData *proto.UsersData) (*proto.ReplyInfo, error) {
+	err := server.trackedUsers.Replace(usersData(userData))
+	if err != nil {
+		err = fmt.Errorf("replace users: %s", err)
+		return &proto.ReplyInfo{
+			Message: err.Error(),
+		}, err
 	}
 	return &proto.ReplyInfo{}, nil
 }
@@ -154,113 +372,114 @@ func (server *Server) GetAddressBalance(_ context.Context, acc *proto.Account) (
 }
 
 func (server *Server) ResyncAddress(_ context.Context, acc *proto.AddressToResync) (*proto.ReplyInfo, error) {
-	//TODO: consider streaming return
 	// TODO: check if account exist?
-
-	log.Debugf("ResyncAddress:resync")
-
-	// check if account is in trackedUsers
-	userData, ok := server.trackedUsers[acc.Address]
-	if !ok {
-		err := fmt.Errorf("user not trackedUsers: %s", acc.Address)
+	_, err := server.startResync(acc)
+	if err != nil {
 		return &proto.ReplyInfo{
 			Message: err.Error(),
 		}, err
 	}
+	return &proto.ReplyInfo{}, nil
+}
 
-	ctx := context.Background()
-	singleTracker := map[string]UserData{acc.Address: userData}
-	handlerCtx, handlerCancel := context.WithTimeout(ctx, resyncTimeout)
-	blockNumCh := make(chan uint32)
-
-	handler := &blockDataHandler{
-		blockNumCh:   blockNumCh,
-		resync:       true,
-		history:      server.historyCh,
-		trackedUsers: singleTracker,
-		name:         fmt.Sprintf("resync %s", acc.Address),
-		ctx:          handlerCtx,
+func (server *Server) ResyncAddressStream(acc *proto.AddressToResync, stream proto.NodeCommunications_ResyncAddressStreamServer) error {
+	job, err := server.startResync(acc)
+	if err != nil {
+		return err
 	}
 
-	info, err := server.api.GetInfo()
-	if err != nil {
-		return &proto.ReplyInfo{
-			Message: err.Error(),
-		}, err
+	ctx := stream.Context()
+	ticker := time.NewTicker(resyncProgressInterval)
+	defer ticker.Stop()
+	for {
+		err = stream.Send(job.Progress())
+		if err != nil {
+			return err
+		}
+		select {
+		case <-job.Done():
+			return stream.Send(job.Progress())
+		case <-ticker.C:
+		case <-ctx.Done():
+			// job keeps running, it can be queried or cancelled by id
+			return ctx.Err()
+		}
 	}
+}
 
-	endBlockNum := info.HeadBlockNum
+func (server *Server) GetResyncJob(_ context.Context, req *proto.ResyncJobID) (*proto.ResyncProgress, error) {
+	job, ok := server.resyncJobs.Get(req.JobID)
+	if !ok {
+		return nil, fmt.Errorf("no resync job: %s", req.JobID)
+	}
+	return job.Progress(), nil
+}
 
-	block, err := server.api.GetBlockByNum(1)
-	if err != nil {
+func (server *Server) CancelResyncJob(_ context.Context, req *proto.ResyncJobID) (*proto.ReplyInfo, error) {
+	job, ok := server.resyncJobs.Get(req.JobID)
+	if !ok {
+		err := fmt.Errorf("no resync job: %s", req.JobID)
 		return &proto.ReplyInfo{
 			Message: err.Error(),
 		}, err
 	}
+	server.resyncScheduler.Cancel(job)
+	return &proto.ReplyInfo{}, nil
+}
 
-	p2pClient := p2p.NewClient(server.p2pAddr, info.ChainID, networkVersion)
-
-	p2pClient.RegisterHandler(handler)
-	go p2pClient.ConnectAndSync(1, block.ID, block.Timestamp.Time, 0, make([]byte, 32))
-
-	log.Debugf("ResyncAddress:sync done")
-
-	go func() {
-		for {
-			select {
-			case blockNum := <-blockNumCh:
-				if blockNum > endBlockNum {
-					log.Debugf("done resync %s", acc.Address)
-					handlerCancel()
-					p2pClient.UnregisterHandler(handler)
-				}
-			}
-		}
-	}()
+func (server *Server) ResyncJobs(_ context.Context, _ *proto.Empty) (*proto.ResyncJobsList, error) {
+	return &proto.ResyncJobsList{
+		Jobs: server.resyncJobs.List(),
+	}, nil
+}
 
-	log.Debugf("return")
+// NewTxStreams gets delivery counters of every NewTx stream
+func (server *Server) NewTxStreams(_ context.Context, _ *proto.Empty) (*proto.NewTxStreamsList, error) {
+	return &proto.NewTxStreamsList{
+		Streams: server.broadcaster.Stats(),
+	}, nil
+}
 
-	err = ctx.Err()
+func (server *Server) GetAccountHistory(_ context.Context, req *proto.AccountHistoryReq) (*proto.AccountHistory, error) {
+	if server.actionIndex == nil {
+		return nil, fmt.Errorf("action index is disabled")
+	}
+	history, err := server.actionIndex.History(req)
 	if err != nil {
-		return &proto.ReplyInfo{
-			Message: err.Error(),
-		}, err
+		return nil, err
 	}
-	return &proto.ReplyInfo{}, nil
+	lib := server.lib.Get()
+	for _, action := range history.Actions {
+		if action.BlockNum <= lib {
+			action.Status = proto.Action_IRREVERSIBLE
+		}
+	}
+	return history, nil
 }
 
 func (server *Server) NewBlock(_ *proto.Empty, stream proto.NodeCommunications_NewBlockServer) error {
-	info, err := server.api.GetInfo()
-	if err != nil {
-		return fmt.Errorf("get_info: %s", err)
-	}
-	p2pClient := p2p.NewClient(server.p2pAddr, info.ChainID, networkVersion)
-	heights := make(chan proto.BlockHeight)
 	ctx := stream.Context()
-	handlerCtx, handlerCancel := context.WithCancel(ctx)
+	heights := make(chan proto.BlockHeight, 1)
 	handler := &blockHeightHandler{
-		ctx:         handlerCtx,
+		ctx:         ctx,
 		blockHeight: heights,
 	}
-	p2pClient.RegisterHandler(handler)
-	defer p2pClient.UnregisterHandler(handler)
-	go p2pClient.ConnectRecent()
+	server.ingestion.Subscribe(handler)
+	defer server.ingestion.Unsubscribe(handler)
 
 	for {
 		select {
 		case <-ctx.Done():
-			handlerCancel()
 			return ctx.Err()
+		case <-server.ctx.Done():
+			return nil
 		case height := <-heights:
-			err = stream.Send(&height)
+			err := stream.Send(&height)
 			if err != nil {
-				handlerCancel()
 				return err
 			}
 		}
 	}
-	return ctx.Err()
-
 }
 
 func (server *Server) SendRawTx(_ context.Context, rawTx *proto.RawTx) (*proto.SendTxResp, error) {
@@ -278,55 +497,182 @@ func (server *Server) SendRawTx(_ context.Context, rawTx *proto.RawTx) (*proto.S
 	}, nil
 }
 
-func (server *Server) NewTx(_ *proto.Empty, stream proto.NodeCommunications_NewTxServer) error {
-	info, err := server.api.GetInfo()
+func (server *Server) NewTx(req *proto.NewTxReq, stream proto.NodeCommunications_NewTxServer) error {
+	ctx := stream.Context()
+	startBlockNum, after, err := server.newTxStart(req)
 	if err != nil {
-		return fmt.Errorf("get_info: %s", err)
+		return err
 	}
+	txs := newTxStream(stream, server.lib, req.IrreversibleOnly,
+		server.broadcaster.Policy(), startBlockNum, after)
 
-	startBlockNum := server.startBlockNum
-	if startBlockNum == 0 {
-		startBlockNum = info.HeadBlockNum
-	}
-	startBlock, err := server.api.GetBlockByNum(startBlockNum)
-	if err != nil {
-		return fmt.Errorf("get_block: %s", err)
+	// catch up before subscription so live stream is not stalled
+	if startBlockNum != 0 {
+		endBlockNum := server.ingestion.LastBlockNum()
+		if endBlockNum == 0 {
+			return fmt.Errorf("blocks ingestion is not ready yet")
+		}
+		if startBlockNum <= endBlockNum {
+			err = server.replayActions(ctx, txs, startBlockNum, endBlockNum, after)
+			if err != nil {
+				return err
+			}
+			startBlockNum = endBlockNum + 1
+		}
 	}
 
-	ctx := stream.Context()
-	handlerCtx, handlerCancel := context.WithCancel(ctx)
-
-	handler := &blockDataHandler{
-		ctx:          handlerCtx,
-		name:         "NewTx",
-		trackedUsers: server.trackedUsers,
-		history:      server.historyCh,
-		resync:       false,
+	name := "NewTx"
+	if p, ok := peer.FromContext(ctx); ok {
+		name = fmt.Sprintf("NewTx %s", p.Addr)
 	}
+	sub := server.broadcaster.Subscribe(name)
+	defer server.broadcaster.Unsubscribe(sub)
 
-	p2pClient := p2p.NewClient(server.p2pAddr, info.ChainID, networkVersion)
-	p2pClient.RegisterHandler(handler)
-	defer p2pClient.UnregisterHandler(handler)
-	go p2pClient.ConnectAndSync(startBlockNum, startBlock.ID, startBlock.Timestamp.Time, 0, make([]byte, 32))
+	// blocks published before subscription are replayed
+	// and then skipped in live stream
+	var replayedBlockNum uint32
+	if startBlockNum != 0 {
+		replayedBlockNum = server.ingestion.LastBlockNum()
+		if startBlockNum <= replayedBlockNum {
+			err = server.replayActions(ctx, txs, startBlockNum, replayedBlockNum, after)
+			if err != nil {
+				return err
+			}
+		} else {
+			replayedBlockNum = startBlockNum - 1
+		}
+	}
 
+	ticker := time.NewTicker(libPollInterval)
+	defer ticker.Stop()
 	for {
 		select {
-		case action := <-server.historyCh:
-			err = stream.Send(&action)
+		case action := <-sub.Actions():
+			if action.Status == proto.Action_REVERTED {
+				// chain is rewound so replayed blocks are not skipped anymore
+				replayedBlockNum = 0
+				after = nil
+			} else if !action.Resync && (action.BlockNum <= replayedBlockNum || !actionAfter(&action, after)) {
+				continue
+			}
+			err := txs.Send(action)
+			if err != nil {
+				return err
+			}
+		case <-ticker.C:
+			err := txs.Flush()
 			if err != nil {
-				handlerCancel()
 				return err
 			}
+		case <-sub.Done():
+			if sub.Disconnected() {
+				return fmt.Errorf("disconnected as slow consumer")
+			}
+			return nil
 		case <-ctx.Done():
-			handlerCancel()
 			return ctx.Err()
+		case <-server.ctx.Done():
+			return nil
 		}
 	}
-	return ctx.Err()
 }
 
+// newTxStart gets block to start NewTx stream with
+// and cursor of the last action client got
+func (server *Server) newTxStart(req *proto.NewTxReq) (uint32, *cursor, error) {
+	startBlockNum := req.StartBlockNum
+	var after *cursor
+	if req.Cursor != "" {
+		pos, err := parseCursor(req.Cursor)
+		if err != nil {
+			return 0, nil, err
+		}
+		startBlockNum = pos.blockNum
+		after = &pos
+	}
+	if req.StartBlockId != "" && startBlockNum != 0 {
+		block, err := server.api.GetBlockByNum(startBlockNum)
+		if err != nil {
+			return 0, nil, fmt.Errorf("get_block: %s", err)
+		}
+		if id := hex.EncodeToString(block.ID); id != req.StartBlockId {
+			return 0, nil, fmt.Errorf("block %d id is %s, not %s", startBlockNum, id, req.StartBlockId)
+		}
+	}
+	return startBlockNum, after, nil
+}
+
+// replayActions sends tracked users' actions from the blocks range to txs
+// skipping actions up to after cursor
+func (server *Server) replayActions(ctx context.Context, txs *txStream, startBlockNum, endBlockNum uint32, after *cursor) error {
+	handlerCtx, handlerCancel := context.WithCancel(ctx)
+	defer handlerCancel()
+	// history is unbuffered so all the actions are received
+	// when replay is done
+	history := make(chan proto.Action)
+	handler := server.newBlockHandler(handlerCtx, fmt.Sprintf("NewTx replay %d-%d", startBlockNum, endBlockNum),
+		server.trackedUsers, history)
+	errCh := make(chan error, 1)
+	go func() {
+		errCh <- server.ingestion.Replay(handlerCtx, startBlockNum, endBlockNum, handler)
+	}()
+	for {
+		select {
+		case action := <-history:
+			if !actionAfter(&action, after) {
+				continue
+			}
+			err := txs.Send(action)
+			if err != nil {
+				return err
+			}
+		case err := <-errCh:
+			return err
+		}
+	}
+}
+
+// actionAfter reports if action goes after cursor, nil cursor means start
+func actionAfter(action *proto.Action, after *cursor) bool {
+	if after == nil {
+		return true
+	}
+	pos, err := parseCursor(action.Cursor)
+	if err != nil {
+		return true
+	}
+	return after.less(pos)
+}
+
+// SyncState replays blocks after height to NewTx streams
+// up to the first block of live ingestion.
+// Deprecated: NewTx takes start block or cursor with request
 func (server *Server) SyncState(_ context.Context, height *proto.BlockHeight) (*proto.ReplyInfo, error) {
-	server.startBlockNum = height.HeadBlockNum
+	endBlockNum := server.ingestion.FirstBlockNum()
+	if endBlockNum == 0 {
+		info, err := server.api.GetInfo()
+		if err != nil {
+			err = fmt.Errorf("get_info: %s", err)
+			return &proto.ReplyInfo{
+				Message: err.Error(),
+			}, err
+		}
+		endBlockNum = info.HeadBlockNum
+	}
+	startBlockNum := height.HeadBlockNum + 1
+	if startBlockNum >= endBlockNum {
+		return &proto.ReplyInfo{}, nil
+	}
+
+	// live handler can't be shared as it keeps delivered actions
+	handler := server.newBlockHandler(server.ctx, fmt.Sprintf("sync state %d", startBlockNum),
+		server.trackedUsers, server.historyCh)
+	go func() {
+		err := server.ingestion.Replay(server.ctx, startBlockNum, endBlockNum-1, handler)
+		if err != nil {
+			log.Errorf("sync state from %d: %s", startBlockNum, err)
+		}
+	}()
 	return &proto.ReplyInfo{}, nil
 }
 
@@ -0,0 +1,105 @@
+/*
+ * Copyright 2018 Idealnaya rabota LLC
+ * Licensed under Multy.io license.
+ * See LICENSE for details
+ */
+
+package eos
+
+import (
+	"context"
+	"reflect"
+	"sort"
+	"testing"
+
+	"github.com/Multy-io/Multy-EOS-node-service/proto"
+)
+
+// trackedAccounts gets sorted accounts tracked by server
+func trackedAccounts(server *Server) []string {
+	var accounts []string
+	for account := range server.trackedUsers.Snapshot() {
+		accounts = append(accounts, account)
+	}
+	sort.Strings(accounts)
+	return accounts
+}
+
+func TestServerUsersRPC(t *testing.T) {
+	ctx := context.Background()
+	initial := &proto.UsersData{Map: map[string]*proto.AddressExtended{
+		"alice": {UserID: "user1"},
+		"bob":   {UserID: "user1", WalletIndex: 1},
+		"carol": {UserID: "user2"},
+	}}
+	tests := []struct {
+		name string
+		call func(server *Server) (*proto.ReplyInfo, error)
+		// fails is set if call must fail without changing accounts
+		fails bool
+		want  []string
+	}{
+		{
+			name: "remove address",
+			call: func(server *Server) (*proto.ReplyInfo, error) {
+				return server.RemoveAddress(ctx, &proto.WatchAddress{Address: "alice", UserID: "user1"})
+			},
+			want: []string{"bob", "carol"},
+		},
+		{
+			name: "remove untracked address",
+			call: func(server *Server) (*proto.ReplyInfo, error) {
+				return server.RemoveAddress(ctx, &proto.WatchAddress{Address: "dave", UserID: "user1"})
+			},
+			fails: true,
+			want:  []string{"alice", "bob", "carol"},
+		},
+		{
+			name: "remove user",
+			call: func(server *Server) (*proto.ReplyInfo, error) {
+				return server.RemoveUser(ctx, &proto.UserID{UserID: "user1"})
+			},
+			want: []string{"carol"},
+		},
+		{
+			name: "remove unknown user",
+			call: func(server *Server) (*proto.ReplyInfo, error) {
+				return server.RemoveUser(ctx, &proto.UserID{UserID: "user3"})
+			},
+			want: []string{"alice", "bob", "carol"},
+		},
+		{
+			name: "replace users data",
+			call: func(server *Server) (*proto.ReplyInfo, error) {
+				return server.ReplaceUsersData(ctx, &proto.UsersData{Map: map[string]*proto.AddressExtended{
+					"carol": {UserID: "user2"},
+					"dave":  {UserID: "user3"},
+				}})
+			},
+			want: []string{"carol", "dave"},
+		},
+		{
+			name: "replace with empty users data",
+			call: func(server *Server) (*proto.ReplyInfo, error) {
+				return server.ReplaceUsersData(ctx, &proto.UsersData{})
+			},
+		},
+	}
+	for _, test := range tests {
+		server := &Server{trackedUsers: newTrackedUsers(nil)}
+		if _, err := server.InitialAdd(ctx, initial); err != nil {
+			t.Fatal(err)
+		}
+		reply, err := test.call(server)
+		if test.fails {
+			if err == nil || reply.Message == "" {
+				t.Errorf("%s: error is not reported", test.name)
+			}
+		} else if err != nil {
+			t.Errorf("%s: %s", test.name, err)
+		}
+		if got := trackedAccounts(server); !reflect.DeepEqual(got, test.want) {
+			t.Errorf("%s: tracked %v, want %v", test.name, got, test.want)
+		}
+	}
+}
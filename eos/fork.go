@@ -0,0 +1,119 @@
+/*
+ * Copyright 2018 Idealnaya rabota LLC
+ * Licensed under Multy.io license.
+ * See LICENSE for details
+ */
+
+package eos
+
+import (
+	"bytes"
+	"sync"
+
+	"github.com/eoscanada/eos-go"
+)
+
+// forkWindow is a number of recent blocks kept to detect forks,
+// it's much more than irreversible block is behind the head
+const forkWindow = 1000
+
+// forkHandler is a block handler which needs to know about forks
+type forkHandler interface {
+	// HandleFork is called when blocks starting with blockNum are orphaned
+	// before the blocks of the new branch are handled
+	HandleFork(blockNum uint32)
+}
+
+// recentBlocks keeps chain of recent live blocks' ids
+type recentBlocks struct {
+	api *eos.API
+
+	mu   sync.Mutex
+	ids  map[uint32]eos.SHA256Bytes
+	last uint32
+}
+
+func newRecentBlocks(api *eos.API) *recentBlocks {
+	return &recentBlocks{
+		api: api,
+		ids: make(map[uint32]eos.SHA256Bytes),
+	}
+}
+
+// Add adds block to the chain. It returns first orphaned block number
+// if block switches the chain to another branch, 0 otherwise,
+// and known is set if block is already in the chain
+func (chain *recentBlocks) Add(block *eos.SignedBlock) (forkBlockNum uint32, known bool) {
+	num := block.BlockNumber()
+	id, err := block.BlockID()
+	if err != nil {
+		log.Errorf("recent blocks: block_id %d: %s", num, err)
+		return 0, false
+	}
+
+	chain.mu.Lock()
+	defer chain.mu.Unlock()
+	if existing, ok := chain.ids[num]; ok && bytes.Equal(existing, id) {
+		return 0, true
+	}
+	if chain.last != 0 && num <= chain.last {
+		forkBlockNum = num
+	}
+	if prevID, ok := chain.ids[num-1]; ok && !bytes.Equal(prevID, block.Previous) {
+		forkBlockNum = chain.forkPoint(num - 1)
+	}
+	if forkBlockNum != 0 {
+		for n := forkBlockNum; n <= chain.last; n++ {
+			delete(chain.ids, n)
+		}
+	}
+
+	chain.ids[num] = id
+	chain.last = num
+	for n := range chain.ids {
+		if n+forkWindow <= num {
+			delete(chain.ids, n)
+		}
+	}
+	return forkBlockNum, false
+}
+
+// AddBranch adds blocks of the new branch preceding the last added block,
+// which orphaned the blocks Add reported
+func (chain *recentBlocks) AddBranch(blocks []*eos.SignedBlock) {
+	chain.mu.Lock()
+	defer chain.mu.Unlock()
+	for _, block := range blocks {
+		num := block.BlockNumber()
+		id, err := block.BlockID()
+		if err != nil {
+			log.Errorf("recent blocks: block_id %d: %s", num, err)
+			continue
+		}
+		if num < chain.last && num+forkWindow > chain.last {
+			chain.ids[num] = id
+		}
+	}
+}
+
+// forkPoint looks for the first block below blockNum
+// which differs from the node's one
+func (chain *recentBlocks) forkPoint(blockNum uint32) uint32 {
+	n := blockNum
+	for ; n > 0; n-- {
+		id, ok := chain.ids[n]
+		if !ok {
+			break
+		}
+		block, err := chain.api.GetBlockByNum(n)
+		if err != nil {
+			// consider it orphaned to be safe
+			log.Errorf("recent blocks: get_block %d: %s", n, err)
+			return n
+		}
+		if bytes.Equal(block.ID, id) {
+			break
+		}
+	}
+	return n + 1
+}
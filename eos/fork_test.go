@@ -0,0 +1,183 @@
+/*
+ * Copyright 2018 Idealnaya rabota LLC
+ * Licensed under Multy.io license.
+ * See LICENSE for details
+ */
+
+package eos
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strconv"
+	"testing"
+
+	"github.com/Multy-io/Multy-EOS-node-service/proto"
+	"github.com/eoscanada/eos-go"
+)
+
+// testBranch makes blocks from startBlockNum to endBlockNum following
+// previous block, blocks of different producers differ
+func testBranch(t *testing.T, previous *eos.SignedBlock, producer eos.AccountName, startBlockNum, endBlockNum uint32) []*eos.SignedBlock {
+	var blocks []*eos.SignedBlock
+	for num := startBlockNum; num <= endBlockNum; num++ {
+		block := testBlock(num)
+		if previous != nil {
+			id, err := previous.BlockID()
+			if err != nil {
+				t.Fatal(err)
+			}
+			block.Previous = id
+		}
+		block.Producer = producer
+		blocks = append(blocks, block)
+		previous = block
+	}
+	return blocks
+}
+
+// blocksServer serves get_block of node's chain
+func blocksServer(t *testing.T, chain []*eos.SignedBlock) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req struct {
+			BlockNumOrID string `json:"block_num_or_id"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			t.Error(err)
+		}
+		num, err := strconv.ParseUint(req.BlockNumOrID, 10, 32)
+		if err != nil || num == 0 || int(num) > len(chain) {
+			http.Error(w, "unknown block", http.StatusInternalServerError)
+			return
+		}
+		block := chain[num-1]
+		id, err := block.BlockID()
+		if err != nil {
+			t.Error(err)
+		}
+		json.NewEncoder(w).Encode(&eos.BlockResp{
+			SignedBlock: *block,
+			ID:          id,
+			BlockNum:    uint32(num),
+		})
+	}))
+}
+
+// forkRecorder records handled blocks and forks
+type forkRecorder struct {
+	events []string
+}
+
+func (recorder *forkRecorder) HandleBlock(block *eos.SignedBlock) {
+	recorder.events = append(recorder.events, fmt.Sprintf("block %d %s", block.BlockNumber(), block.Producer))
+}
+
+func (recorder *forkRecorder) HandleFork(blockNum uint32) {
+	recorder.events = append(recorder.events, fmt.Sprintf("fork %d", blockNum))
+}
+
+func testIngestion(api *eos.API, handlers ...blockHandler) *blockIngestion {
+	ingestion := &blockIngestion{
+		api:      api,
+		handlers: make(map[blockHandler]struct{}),
+		chain:    newRecentBlocks(api),
+		ctx:      context.Background(),
+	}
+	for _, handler := range handlers {
+		ingestion.Subscribe(handler)
+	}
+	return ingestion
+}
+
+func TestIngestionForkByPreviousBlock(t *testing.T) {
+	common := testBranch(t, nil, "a", 1, 3)
+	orphaned := testBranch(t, common[2], "a", 4, 5)
+	branch := testBranch(t, common[2], "b", 4, 7)
+	node := blocksServer(t, append(append([]*eos.SignedBlock{}, common...), branch...))
+	defer node.Close()
+
+	recorder := &forkRecorder{}
+	history := make(chan proto.Action, 16)
+	handler := &blockDataHandler{
+		ctx:          context.Background(),
+		history:      history,
+		trackedUsers: newTrackedUsers(nil),
+		// actions sent earlier from blocks 3-5
+		delivered: map[uint32][]proto.Action{
+			3: {{BlockNum: 3, Cursor: "3"}},
+			4: {{BlockNum: 4, Cursor: "4a"}, {BlockNum: 4, Cursor: "4b"}},
+			5: {{BlockNum: 5, Cursor: "5"}},
+		},
+	}
+	ingestion := testIngestion(eos.New(node.URL), recorder, handler)
+	for _, block := range append(append([]*eos.SignedBlock{}, common...), orphaned...) {
+		ingestion.publish(block)
+	}
+	// node switched to the branch and sends its block 6 first
+	ingestion.publish(branch[2])
+	// the branch goes on as usual
+	ingestion.publish(branch[3])
+
+	want := []string{
+		"block 1 a", "block 2 a", "block 3 a", "block 4 a", "block 5 a",
+		"fork 4",
+		"block 4 b", "block 5 b", "block 6 b",
+		"block 7 b",
+	}
+	if !reflect.DeepEqual(recorder.events, want) {
+		t.Errorf("events %v,\nwant %v", recorder.events, want)
+	}
+	if last := ingestion.LastBlockNum(); last != 7 {
+		t.Errorf("last block is %d, want 7", last)
+	}
+
+	// actions of orphaned blocks are reverted from the latest
+	close(history)
+	var reverted []string
+	for action := range history {
+		if action.Status != proto.Action_REVERTED {
+			t.Errorf("action %s status is %s", action.Cursor, action.Status)
+		}
+		reverted = append(reverted, action.Cursor)
+	}
+	if want := []string{"5", "4b", "4a"}; !reflect.DeepEqual(reverted, want) {
+		t.Errorf("reverted %v, want %v", reverted, want)
+	}
+	if _, ok := handler.delivered[3]; !ok {
+		t.Error("actions of block 3 are forgotten")
+	}
+}
+
+func TestIngestionForkBySameBlockNum(t *testing.T) {
+	common := testBranch(t, nil, "a", 1, 3)
+	orphaned := testBranch(t, common[2], "a", 4, 5)
+	branch := testBranch(t, common[2], "b", 4, 5)
+	// no requests are expected
+	node := blocksServer(t, nil)
+	defer node.Close()
+
+	recorder := &forkRecorder{}
+	ingestion := testIngestion(eos.New(node.URL), recorder)
+	for _, block := range append(append([]*eos.SignedBlock{}, common...), orphaned...) {
+		ingestion.publish(block)
+	}
+	// node resends the branch from the fork point
+	for _, block := range branch {
+		ingestion.publish(block)
+	}
+	// known block is skipped
+	ingestion.publish(branch[1])
+
+	want := []string{
+		"block 1 a", "block 2 a", "block 3 a", "block 4 a", "block 5 a",
+		"fork 4", "block 4 b",
+		"block 5 b",
+	}
+	if !reflect.DeepEqual(recorder.events, want) {
+		t.Errorf("events %v,\nwant %v", recorder.events, want)
+	}
+}
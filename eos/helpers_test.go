@@ -0,0 +1,58 @@
+/*
+ * Copyright 2018 Idealnaya rabota LLC
+ * Licensed under Multy.io license.
+ * See LICENSE for details
+ */
+
+package eos
+
+import (
+	"context"
+	"encoding/binary"
+
+	"github.com/Multy-io/Multy-EOS-node-service/proto"
+	"github.com/eoscanada/eos-go"
+)
+
+// testBlock makes empty block with given number,
+// block number is got from the previous block id
+func testBlock(num uint32) *eos.SignedBlock {
+	previous := make(eos.SHA256Bytes, 32)
+	binary.BigEndian.PutUint32(previous, num-1)
+	block := &eos.SignedBlock{}
+	block.Previous = previous
+	return block
+}
+
+// testUsers makes users data tracking accounts, user id is the account
+func testUsers(accounts ...string) map[string][]UserData {
+	users := make(map[string][]UserData, len(accounts))
+	for _, account := range accounts {
+		users[account] = []UserData{{UserID: account}}
+	}
+	return users
+}
+
+// mapActions maps actions of block 7 with handler tracking users
+// and gets sent actions without their users and positions
+func mapActions(handler *blockDataHandler, users map[string][]UserData, actions ...*eos.Action) []proto.Action {
+	history := make(chan proto.Action, 64)
+	handler.ctx = context.Background()
+	handler.history = history
+	handler.trackedUsers = newTrackedUsers(users)
+	for i, action := range actions {
+		pos := cursor{blockNum: 7, actionIndex: uint32(i)}
+		handler.processAction(handler.trackedUsers.Snapshot(), action, pos, nil)
+	}
+	close(history)
+
+	var sent []proto.Action
+	for action := range history {
+		action.UserID = ""
+		action.Cursor = ""
+		action.BlockNum = 0
+		action.ActionIndex = 0
+		sent = append(sent, action)
+	}
+	return sent
+}
@@ -0,0 +1,242 @@
+/*
+ * Copyright 2018 Idealnaya rabota LLC
+ * Licensed under Multy.io license.
+ * See LICENSE for details
+ */
+
+package eos
+
+import (
+	"bytes"
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"time"
+
+	"github.com/eoscanada/eos-go"
+)
+
+const (
+	// historyPageSize is a number of actions requested from history api at once
+	historyPageSize = 100
+	// historyRequestTimeout limits get_actions request, so resync
+	// falls back to p2p instead of waiting for stuck node
+	historyRequestTimeout = 30 * time.Second
+)
+
+// errHistoryUnavailable is returned if node has no history_api_plugin
+var errHistoryUnavailable = errors.New("history api is unavailable")
+
+// historyAPI is a client of node's history_api_plugin
+type historyAPI struct {
+	rpcAddr string
+	client  *http.Client
+}
+
+func newHistoryAPI(rpcAddr string) *historyAPI {
+	return &historyAPI{
+		rpcAddr: rpcAddr,
+		client:  &http.Client{Timeout: historyRequestTimeout},
+	}
+}
+
+// historyAction is an action trace got from get_actions
+type historyAction struct {
+	GlobalActionSeq  uint64       `json:"global_action_seq"`
+	AccountActionSeq int64        `json:"account_action_seq"`
+	BlockNum         uint32       `json:"block_num"`
+	BlockTime        eos.JSONTime `json:"block_time"`
+	ActionTrace      struct {
+		Receipt struct {
+			Receiver  eos.AccountName `json:"receiver"`
+			ActDigest string          `json:"act_digest"`
+		} `json:"receipt"`
+		Act   eos.Action `json:"act"`
+		TrxID string     `json:"trx_id"`
+	} `json:"action_trace"`
+}
+
+// GetActions gets account's actions starting with pos
+func (api *historyAPI) GetActions(account string, pos, count int64) ([]historyAction, error) {
+	return api.getActions(account, pos, count-1)
+}
+
+// LastActionSeq gets account action seq of the last account's action,
+// -1 if account has no actions
+func (api *historyAPI) LastActionSeq(account string) (int64, error) {
+	// negative pos is counted from the last action
+	actions, err := api.getActions(account, -1, -1)
+	if err != nil {
+		return 0, err
+	}
+	if len(actions) == 0 {
+		return -1, nil
+	}
+	return actions[len(actions)-1].AccountActionSeq, nil
+}
+
+func (api *historyAPI) getActions(account string, pos, offset int64) ([]historyAction, error) {
+	reqJSON, err := json.Marshal(map[string]interface{}{
+		"account_name": account,
+		"pos":          pos,
+		"offset":       offset,
+	})
+	if err != nil {
+		return nil, err
+	}
+	resp, err := api.client.Post(fmt.Sprintf("%s/v1/history/get_actions", api.rpcAddr),
+		"application/json", bytes.NewReader(reqJSON))
+	if err != nil {
+		return nil, fmt.Errorf("get_actions: %s", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode == http.StatusNotFound {
+		return nil, errHistoryUnavailable
+	}
+	if resp.StatusCode != http.StatusOK {
+		bs, _ := ioutil.ReadAll(resp.Body)
+		return nil, fmt.Errorf("get_actions: response not ok: %v", string(bs))
+	}
+
+	var actions struct {
+		Actions []historyAction `json:"actions"`
+	}
+	err = json.NewDecoder(resp.Body).Decode(&actions)
+	if err != nil {
+		return nil, fmt.Errorf("get_actions: %s", err)
+	}
+	return actions.Actions, nil
+}
+
+// SeekBlock gets pos of the first account's action in startBlockNum
+// or after it, it's a binary search by account action seq
+func (api *historyAPI) SeekBlock(ctx context.Context, account string, startBlockNum uint32) (int64, error) {
+	last, err := api.LastActionSeq(account)
+	if err != nil {
+		return 0, err
+	}
+	lo, hi := int64(0), last+1
+	for lo < hi {
+		select {
+		case <-ctx.Done():
+			return 0, ctx.Err()
+		default:
+		}
+		mid := lo + (hi-lo)/2
+		actions, err := api.GetActions(account, mid, 1)
+		if err != nil {
+			return 0, err
+		}
+		if len(actions) == 0 {
+			// node keeps no actions before mid
+			lo = mid + 1
+			continue
+		}
+		if actions[0].BlockNum < startBlockNum {
+			lo = mid + 1
+		} else {
+			hi = mid
+		}
+	}
+	return lo, nil
+}
+
+// fetchHistory sends job's account actions got from history api.
+// Action's position in transaction is unknown,
+// so only cursor's block is set
+func (s *resyncScheduler) fetchHistory(ctx context.Context, job *resyncJob) error {
+	start, err := s.history.SeekBlock(ctx, job.address, job.startBlockNum)
+	if err != nil {
+		return err
+	}
+	users := job.handler.trackedUsers.Snapshot()
+	var trx historyTrx
+	for pos := start; ; pos += historyPageSize {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		default:
+		}
+		actions, err := s.history.GetActions(job.address, pos, historyPageSize)
+		if err != nil {
+			return err
+		}
+		for i := range actions {
+			action := &actions[i]
+			if action.BlockNum < job.startBlockNum {
+				continue
+			}
+			if action.BlockNum > job.endBlockNum {
+				return s.sendHistoryTrx(job, users, &trx)
+			}
+			if action.ActionTrace.TrxID != trx.id {
+				err = s.sendHistoryTrx(job, users, &trx)
+				if err != nil {
+					return err
+				}
+				trx.id = action.ActionTrace.TrxID
+			}
+			trx.actions = append(trx.actions, action)
+		}
+		if len(actions) < historyPageSize {
+			return s.sendHistoryTrx(job, users, &trx)
+		}
+	}
+}
+
+// historyTrx is account's actions of single transaction
+type historyTrx struct {
+	id      string
+	actions []*historyAction
+	// sent are global seqs of sent actions
+	sent map[uint64]struct{}
+}
+
+// sendHistoryTrx sends actions of transaction once. Account's history has
+// a trace of every action receipt: execution by contract and notifications
+// of every receiver, so account's own receipt is sent or
+// the execution if account isn't notified
+func (s *resyncScheduler) sendHistoryTrx(job *resyncJob, users usersSnapshot, trx *historyTrx) error {
+	defer func() {
+		trx.actions = trx.actions[:0]
+	}()
+	if len(trx.actions) == 0 {
+		return nil
+	}
+	if trx.sent == nil {
+		trx.sent = make(map[uint64]struct{})
+	}
+	notified := make(map[string]struct{})
+	for _, action := range trx.actions {
+		if action.ActionTrace.Receipt.Receiver == eos.AccountName(job.address) {
+			notified[action.ActionTrace.Receipt.ActDigest] = struct{}{}
+		}
+	}
+	transactionID, err := hex.DecodeString(trx.id)
+	if err != nil {
+		return fmt.Errorf("bad trx_id %s: %s", trx.id, err)
+	}
+	for _, action := range trx.actions {
+		receipt := action.ActionTrace.Receipt
+		if receipt.Receiver != eos.AccountName(job.address) {
+			if receipt.Receiver != action.ActionTrace.Act.Account {
+				continue
+			}
+			if _, ok := notified[receipt.ActDigest]; ok {
+				continue
+			}
+		}
+		if _, ok := trx.sent[action.GlobalActionSeq]; ok {
+			continue
+		}
+		trx.sent[action.GlobalActionSeq] = struct{}{}
+		job.handler.processAction(users, &action.ActionTrace.Act,
+			cursor{blockNum: action.BlockNum}, transactionID)
+		job.setCurrentBlockNum(action.BlockNum)
+	}
+	return nil
+}
@@ -0,0 +1,100 @@
+/*
+ * Copyright 2018 Idealnaya rabota LLC
+ * Licensed under Multy.io license.
+ * See LICENSE for details
+ */
+
+package eos
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+// historyServer serves get_actions of single account,
+// blocks[seq] is block of action with account action seq
+func historyServer(t *testing.T, blocks []uint32, requests *int) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		*requests++
+		var req struct {
+			Pos    int64 `json:"pos"`
+			Offset int64 `json:"offset"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			t.Error(err)
+		}
+		from, to := req.Pos, req.Pos+req.Offset
+		if req.Pos < 0 {
+			from, to = int64(len(blocks))+req.Offset, int64(len(blocks))-1
+		}
+		var resp struct {
+			Actions []historyAction `json:"actions"`
+		}
+		for seq := from; seq <= to && seq < int64(len(blocks)); seq++ {
+			if seq < 0 {
+				continue
+			}
+			var action historyAction
+			action.AccountActionSeq = seq
+			action.GlobalActionSeq = uint64(1000 + seq)
+			action.BlockNum = blocks[seq]
+			resp.Actions = append(resp.Actions, action)
+		}
+		json.NewEncoder(w).Encode(resp)
+	}))
+}
+
+func TestHistorySeekBlock(t *testing.T) {
+	var blocks []uint32
+	for num := uint32(1); num <= 1000; num++ {
+		// two actions in every block
+		blocks = append(blocks, num, num)
+	}
+	requests := 0
+	server := historyServer(t, blocks, &requests)
+	defer server.Close()
+	api := newHistoryAPI(server.URL)
+
+	cases := []struct {
+		startBlockNum uint32
+		want          int64
+	}{
+		{0, 0},
+		{1, 0},
+		{2, 2},
+		{500, 998},
+		{1000, 1998},
+		{1001, 2000},
+	}
+	for _, c := range cases {
+		requests = 0
+		pos, err := api.SeekBlock(context.Background(), "alice", c.startBlockNum)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if pos != c.want {
+			t.Errorf("block %d is at pos %d, want %d", c.startBlockNum, pos, c.want)
+		}
+		if requests > 13 {
+			t.Errorf("block %d is found in %d requests", c.startBlockNum, requests)
+		}
+	}
+}
+
+func TestHistorySeekBlockNoActions(t *testing.T) {
+	requests := 0
+	server := historyServer(t, nil, &requests)
+	defer server.Close()
+	api := newHistoryAPI(server.URL)
+
+	pos, err := api.SeekBlock(context.Background(), "alice", 10)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if pos != 0 || requests != 1 {
+		t.Errorf("pos is %d after %d requests, want 0 after 1", pos, requests)
+	}
+}
@@ -0,0 +1,415 @@
+/*
+ * Copyright 2018 Idealnaya rabota LLC
+ * Licensed under Multy.io license.
+ * See LICENSE for details
+ */
+
+package eos
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/eoscanada/eos-go"
+	"github.com/eoscanada/eos-go/p2p"
+)
+
+// reconnectDelay is a pause before reconnecting to p2p node
+const reconnectDelay = 5 * time.Second
+
+// p2pSyncer is a p2p connection syncing blocks
+type p2pSyncer interface {
+	RegisterHandler(handler p2p.Handler)
+	UnregisterHandler(handler p2p.Handler)
+	// Sync connects and blocks until connection fails or is closed
+	Sync() error
+	// Close closes connection making Sync return
+	Close() error
+}
+
+// blockHandler processes blocks received from p2p node
+type blockHandler interface {
+	HandleBlock(block *eos.SignedBlock)
+}
+
+// blockIngestion owns single live p2p connection
+// and publishes received blocks to every subscribed handler
+type blockIngestion struct {
+	api     *eos.API
+	p2pAddr string
+
+	mu       sync.Mutex
+	handlers map[blockHandler]struct{}
+
+	// first and last live blocks received
+	firstBlockNum uint32
+	lastBlockNum  uint32
+	// chain of recent live blocks to detect forks
+	chain *recentBlocks
+	// archive keeps received blocks for replays, may be nil
+	archive BlockArchive
+	// lib limits replayed blocks archived to irreversible ones,
+	// as only live blocks are checked for forks. It may be nil
+	lib *libTracker
+	// dial makes p2p connection syncing from given block, 0 for head block
+	dial func(startBlockNum uint32) (p2pSyncer, error)
+
+	ctx    context.Context
+	cancel context.CancelFunc
+	done   chan struct{}
+}
+
+func newBlockIngestion(api *eos.API, p2pAddr string, lib *libTracker) *blockIngestion {
+	ingestion := &blockIngestion{
+		api:      api,
+		p2pAddr:  p2pAddr,
+		lib:      lib,
+		handlers: make(map[blockHandler]struct{}),
+		chain:    newRecentBlocks(api),
+		done:     make(chan struct{}),
+	}
+	ingestion.dial = ingestion.dialNode
+	return ingestion
+}
+
+// SetArchive sets archive for received blocks,
+// it must be called before Start
+func (ingestion *blockIngestion) SetArchive(archive BlockArchive) {
+	ingestion.archive = archive
+}
+
+// Start connects to p2p node and syncs blocks starting with startBlockNum,
+// 0 for head block. Connection is restored from the last received block
+func (ingestion *blockIngestion) Start(startBlockNum uint32) {
+	ingestion.ctx, ingestion.cancel = context.WithCancel(context.Background())
+	go ingestion.run(startBlockNum)
+}
+
+// Stop stops publishing blocks and waits for ingestion to finish
+func (ingestion *blockIngestion) Stop() {
+	if ingestion.cancel == nil {
+		return
+	}
+	ingestion.cancel()
+	<-ingestion.done
+}
+
+// Subscribe adds handler to live blocks stream
+func (ingestion *blockIngestion) Subscribe(handler blockHandler) {
+	ingestion.mu.Lock()
+	defer ingestion.mu.Unlock()
+	ingestion.handlers[handler] = struct{}{}
+}
+
+// Unsubscribe removes handler from live blocks stream
+func (ingestion *blockIngestion) Unsubscribe(handler blockHandler) {
+	ingestion.mu.Lock()
+	defer ingestion.mu.Unlock()
+	delete(ingestion.handlers, handler)
+}
+
+// FirstBlockNum gets number of first live block, 0 if no blocks received yet
+func (ingestion *blockIngestion) FirstBlockNum() uint32 {
+	return atomic.LoadUint32(&ingestion.firstBlockNum)
+}
+
+// LastBlockNum gets number of last published live block, 0 if no blocks received yet
+func (ingestion *blockIngestion) LastBlockNum() uint32 {
+	return atomic.LoadUint32(&ingestion.lastBlockNum)
+}
+
+func (ingestion *blockIngestion) run(startBlockNum uint32) {
+	defer close(ingestion.done)
+	for {
+		err := ingestion.connect(startBlockNum)
+		select {
+		case <-ingestion.ctx.Done():
+			return
+		default:
+		}
+		log.Errorf("ingestion: %v, reconnect in %s", err, reconnectDelay)
+		if last := atomic.LoadUint32(&ingestion.lastBlockNum); last != 0 {
+			startBlockNum = last + 1
+		}
+
+		select {
+		case <-ingestion.ctx.Done():
+			return
+		case <-time.After(reconnectDelay):
+		}
+	}
+}
+
+// connect makes new p2p connection and waits for it to fail
+// or for ingestion to stop
+func (ingestion *blockIngestion) connect(startBlockNum uint32) error {
+	client, err := ingestion.dial(startBlockNum)
+	if err != nil {
+		return err
+	}
+	return syncUntil(ingestion.ctx, client, &ingestionConn{ingestion: ingestion}, nil)
+}
+
+// dialNode makes p2p connection to node syncing from startBlockNum
+func (ingestion *blockIngestion) dialNode(startBlockNum uint32) (p2pSyncer, error) {
+	info, err := ingestion.api.GetInfo()
+	if err != nil {
+		return nil, fmt.Errorf("get_info: %s", err)
+	}
+	if startBlockNum == 0 {
+		startBlockNum = info.HeadBlockNum
+	}
+	return newSyncClient(ingestion.api, ingestion.p2pAddr, info.ChainID, startBlockNum)
+}
+
+// syncUntil syncs client until connection fails, done is closed or ctx is done.
+// Connection is closed and Sync is waited for, so nothing is left running
+func syncUntil(ctx context.Context, client p2pSyncer, handler p2p.Handler, done <-chan struct{}) error {
+	client.RegisterHandler(handler)
+	defer client.UnregisterHandler(handler)
+
+	errCh := make(chan error, 1)
+	go func() {
+		errCh <- client.Sync()
+	}()
+	var err error
+	select {
+	case err = <-errCh:
+		client.Close()
+		return fmt.Errorf("p2p: %v", err)
+	case <-done:
+	case <-ctx.Done():
+		err = ctx.Err()
+	}
+	client.Close()
+	<-errCh
+	return err
+}
+
+func (ingestion *blockIngestion) publish(block *eos.SignedBlock) {
+	select {
+	case <-ingestion.ctx.Done():
+		return
+	default:
+	}
+	num := block.BlockNumber()
+	forkBlockNum, known := ingestion.chain.Add(block)
+	if known {
+		return
+	}
+	// fork found by previous block id is below the block, so blocks
+	// of the new branch before it are got from node
+	blocks := []*eos.SignedBlock{block}
+	if forkBlockNum != 0 && forkBlockNum < num {
+		branch, err := ingestion.fetchBlocks(forkBlockNum, num-1)
+		if err != nil {
+			return
+		}
+		ingestion.chain.AddBranch(branch)
+		blocks = append(branch, block)
+	}
+	atomic.CompareAndSwapUint32(&ingestion.firstBlockNum, 0, num)
+	atomic.StoreUint32(&ingestion.lastBlockNum, num)
+	if ingestion.archive != nil {
+		if forkBlockNum != 0 {
+			ingestion.archive.Fork(forkBlockNum)
+		}
+		for _, block := range blocks {
+			ingestion.archive.Put(block)
+		}
+	}
+
+	ingestion.mu.Lock()
+	handlers := make([]blockHandler, 0, len(ingestion.handlers))
+	for handler := range ingestion.handlers {
+		handlers = append(handlers, handler)
+	}
+	ingestion.mu.Unlock()
+
+	if forkBlockNum != 0 {
+		log.Warnf("ingestion: fork, blocks from %d are orphaned by %d", forkBlockNum, num)
+		for _, handler := range handlers {
+			if handler, ok := handler.(forkHandler); ok {
+				handler.HandleFork(forkBlockNum)
+			}
+		}
+	}
+	for _, block := range blocks {
+		for _, handler := range handlers {
+			handler.HandleBlock(block)
+		}
+	}
+}
+
+// fetchBlocks gets blocks from startBlockNum to endBlockNum inclusively
+// from node. Failed request is retried until ingestion is stopped
+func (ingestion *blockIngestion) fetchBlocks(startBlockNum, endBlockNum uint32) ([]*eos.SignedBlock, error) {
+	blocks := make([]*eos.SignedBlock, 0, endBlockNum-startBlockNum+1)
+	for num := startBlockNum; num <= endBlockNum; {
+		resp, err := ingestion.api.GetBlockByNum(num)
+		if err != nil {
+			log.Errorf("ingestion: get_block %d: %s, retry in %s", num, err, reconnectDelay)
+			select {
+			case <-ingestion.ctx.Done():
+				return nil, ingestion.ctx.Err()
+			case <-time.After(reconnectDelay):
+			}
+			continue
+		}
+		blocks = append(blocks, &resp.SignedBlock)
+		num++
+	}
+	return blocks, nil
+}
+
+// Replay syncs blocks from startBlockNum to endBlockNum inclusively
+// reading archive first and then using separate p2p connection,
+// so live stream is not affected.
+// Replay returns after endBlockNum is handled or ctx is done
+func (ingestion *blockIngestion) Replay(ctx context.Context, startBlockNum, endBlockNum uint32, handler blockHandler) error {
+	if ingestion.archive != nil {
+		var err error
+		startBlockNum, err = ingestion.replayArchive(ctx, startBlockNum, endBlockNum, handler)
+		if err != nil {
+			return err
+		}
+		if startBlockNum > endBlockNum {
+			return nil
+		}
+	}
+
+	client, err := ingestion.dial(startBlockNum)
+	if err != nil {
+		return err
+	}
+	replay := &replayConn{
+		archive:     ingestion.archive,
+		lib:         ingestion.lib,
+		handler:     handler,
+		endBlockNum: endBlockNum,
+		done:        make(chan struct{}),
+	}
+	return syncUntil(ctx, client, replay, replay.done)
+}
+
+// replayArchive handles archived blocks while they are contiguous.
+// It returns number of the first block missing in archive
+func (ingestion *blockIngestion) replayArchive(ctx context.Context, startBlockNum, endBlockNum uint32, handler blockHandler) (uint32, error) {
+	for startBlockNum <= endBlockNum {
+		select {
+		case <-ctx.Done():
+			return 0, ctx.Err()
+		default:
+		}
+		blocks, err := ingestion.archive.Blocks(startBlockNum, archiveReadSize)
+		if err != nil {
+			log.Errorf("ingestion: archive: %s", err)
+			return startBlockNum, nil
+		}
+		for _, block := range blocks {
+			if startBlockNum > endBlockNum {
+				break
+			}
+			handler.HandleBlock(block)
+			startBlockNum++
+		}
+		if len(blocks) < archiveReadSize {
+			break
+		}
+	}
+	return startBlockNum, nil
+}
+
+// syncClient is a p2p client which syncs from given block.
+// It connects through proxy as p2p client can't be closed
+type syncClient struct {
+	*p2p.Client
+	proxy *p2pProxy
+
+	headBlockNum  uint32
+	headBlockID   eos.SHA256Bytes
+	headBlockTime time.Time
+}
+
+// newSyncClient makes client which syncs blocks starting with startBlockNum.
+// Node sends blocks after the head we declare so it is the previous block
+func newSyncClient(api *eos.API, p2pAddr string, chainID eos.SHA256Bytes, startBlockNum uint32) (*syncClient, error) {
+	client := &syncClient{
+		// head 0 with empty id makes node send blocks from genesis
+		headBlockID: make(eos.SHA256Bytes, 32),
+	}
+	if startBlockNum > 1 {
+		client.headBlockNum = startBlockNum - 1
+		block, err := api.GetBlockByNum(client.headBlockNum)
+		if err != nil {
+			return nil, fmt.Errorf("get_block %d: %s", client.headBlockNum, err)
+		}
+		client.headBlockID = block.ID
+		client.headBlockTime = block.Timestamp.Time
+	}
+	proxy, err := newP2PProxy(p2pAddr)
+	if err != nil {
+		return nil, err
+	}
+	client.Client = p2p.NewClient(proxy.Addr(), chainID, networkVersion)
+	client.proxy = proxy
+	return client, nil
+}
+
+// Sync connects to node and blocks until connection fails
+func (client *syncClient) Sync() error {
+	return client.ConnectAndSync(client.headBlockNum, client.headBlockID, client.headBlockTime, 0, make([]byte, 32))
+}
+
+// Close closes connection to node
+func (client *syncClient) Close() error {
+	return client.proxy.Close()
+}
+
+// ingestionConn decodes live p2p messages
+type ingestionConn struct {
+	ingestion *blockIngestion
+}
+
+func (conn *ingestionConn) Handle(msg p2p.Message) {
+	if msg.Envelope.Type == eos.SignedBlockType {
+		conn.ingestion.publish(msg.Envelope.P2PMessage.(*eos.SignedBlock))
+	}
+}
+
+// replayConn decodes p2p messages of replayed range
+type replayConn struct {
+	archive     BlockArchive
+	lib         *libTracker
+	handler     blockHandler
+	endBlockNum uint32
+
+	done     chan struct{}
+	doneOnce sync.Once
+}
+
+func (conn *replayConn) Handle(msg p2p.Message) {
+	if msg.Envelope.Type != eos.SignedBlockType {
+		return
+	}
+	select {
+	case <-conn.done:
+		return
+	default:
+	}
+	block := msg.Envelope.P2PMessage.(*eos.SignedBlock)
+	if block.BlockNumber() > conn.endBlockNum {
+		conn.doneOnce.Do(func() { close(conn.done) })
+		return
+	}
+	if conn.archive != nil && conn.lib != nil && block.BlockNumber() <= conn.lib.Get() {
+		conn.archive.Put(block)
+	}
+	conn.handler.HandleBlock(block)
+	if block.BlockNumber() == conn.endBlockNum {
+		conn.doneOnce.Do(func() { close(conn.done) })
+	}
+}
@@ -0,0 +1,208 @@
+/*
+ * Copyright 2018 Idealnaya rabota LLC
+ * Licensed under Multy.io license.
+ * See LICENSE for details
+ */
+
+package eos
+
+import (
+	"context"
+	"fmt"
+	"sync/atomic"
+	"time"
+
+	"github.com/Multy-io/Multy-EOS-node-service/proto"
+	"github.com/eoscanada/eos-go"
+)
+
+// libPollInterval is an interval for polling last irreversible block
+const libPollInterval = time.Second
+
+// maxPendingActions limits actions held by NewTx stream
+// until their blocks are irreversible
+const maxPendingActions = 10000
+
+// libTracker keeps last irreversible block number up to date
+type libTracker struct {
+	api *eos.API
+
+	lastIrreversibleBlockNum uint32
+}
+
+func newLIBTracker(api *eos.API) *libTracker {
+	return &libTracker{
+		api: api,
+	}
+}
+
+// Get gets last irreversible block number known
+func (tracker *libTracker) Get() uint32 {
+	return atomic.LoadUint32(&tracker.lastIrreversibleBlockNum)
+}
+
+// Run polls node until ctx is done
+func (tracker *libTracker) Run(ctx context.Context) {
+	ticker := time.NewTicker(libPollInterval)
+	defer ticker.Stop()
+	for {
+		tracker.update()
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (tracker *libTracker) update() {
+	info, err := tracker.api.GetInfo()
+	if err != nil {
+		log.Warnf("lib tracker: get_info: %s", err)
+		return
+	}
+	atomic.StoreUint32(&tracker.lastIrreversibleBlockNum, info.LastIrreversibleBlockNum)
+}
+
+// txStream sends actions to NewTx stream marking their status.
+// In irreversible only mode actions are held until their block is irreversible
+type txStream struct {
+	stream           proto.NodeCommunications_NewTxServer
+	lib              *libTracker
+	irreversibleOnly bool
+	// policy is applied when maxPending actions are held
+	policy     SlowConsumerPolicy
+	maxPending int
+	// startBlockNum and after are where client resumed from,
+	// client may have got actions before them earlier
+	startBlockNum uint32
+	after         *cursor
+
+	// pending actions in chain order
+	pending []proto.Action
+	// sent are actions sent as pending in chain order,
+	// only they and actions got before resume are reverted
+	sent []proto.Action
+}
+
+func newTxStream(stream proto.NodeCommunications_NewTxServer, lib *libTracker, irreversibleOnly bool,
+	policy SlowConsumerPolicy, startBlockNum uint32, after *cursor) *txStream {
+	return &txStream{
+		stream:           stream,
+		lib:              lib,
+		irreversibleOnly: irreversibleOnly,
+		policy:           policy,
+		maxPending:       maxPendingActions,
+		startBlockNum:    startBlockNum,
+		after:            after,
+	}
+}
+
+// Send sends action or holds it until irreversible.
+// Reverted action which is held is just dropped,
+// reverted action client never got is skipped
+func (s *txStream) Send(action proto.Action) error {
+	err := s.Flush()
+	if err != nil {
+		return err
+	}
+	if action.Status == proto.Action_REVERTED {
+		var held, sent bool
+		if s.pending, held = removeAction(s.pending, action); held {
+			return nil
+		}
+		if s.sent, sent = removeAction(s.sent, action); !sent && !s.beforeStart(&action) {
+			// client never got the action
+			return nil
+		}
+		return s.stream.Send(&action)
+	}
+	if action.BlockNum <= s.lib.Get() {
+		action.Status = proto.Action_IRREVERSIBLE
+		return s.stream.Send(&action)
+	}
+	if s.irreversibleOnly {
+		return s.hold(action)
+	}
+	action.Status = proto.Action_PENDING
+	err = s.stream.Send(&action)
+	if err != nil {
+		return err
+	}
+	if len(s.sent) >= s.maxPending {
+		// the oldest one is deep enough not to be reverted
+		s.sent = s.sent[1:]
+	}
+	s.sent = append(s.sent, action)
+	return nil
+}
+
+// hold keeps action until its block is irreversible. If too many actions
+// are held slow consumer policy is applied: the oldest action is dropped,
+// stream is disconnected or waits for irreversible block
+// making broadcaster apply the policy to the stream's subscription
+func (s *txStream) hold(action proto.Action) error {
+	for len(s.pending) >= s.maxPending {
+		switch s.policy {
+		case PolicyDropOldest:
+			log.Warnf("NewTx: %d actions wait for irreversible block, dropping the oldest", len(s.pending))
+			s.pending = s.pending[1:]
+		case PolicyDisconnect:
+			return fmt.Errorf("disconnected as slow consumer: %d actions wait for irreversible block", len(s.pending))
+		default:
+			select {
+			case <-s.stream.Context().Done():
+				return s.stream.Context().Err()
+			case <-time.After(libPollInterval):
+			}
+			err := s.Flush()
+			if err != nil {
+				return err
+			}
+		}
+	}
+	s.pending = append(s.pending, action)
+	return nil
+}
+
+// Flush sends held actions which became irreversible
+// and forgets sent ones which can't be reverted anymore
+func (s *txStream) Flush() error {
+	lib := s.lib.Get()
+	sent := 0
+	for ; sent < len(s.pending) && s.pending[sent].BlockNum <= lib; sent++ {
+		action := s.pending[sent]
+		action.Status = proto.Action_IRREVERSIBLE
+		err := s.stream.Send(&action)
+		if err != nil {
+			return err
+		}
+	}
+	s.pending = s.pending[sent:]
+
+	irreversible := 0
+	for irreversible < len(s.sent) && s.sent[irreversible].BlockNum <= lib {
+		irreversible++
+	}
+	s.sent = s.sent[irreversible:]
+	return nil
+}
+
+// beforeStart reports if action goes before the position stream resumed from
+func (s *txStream) beforeStart(action *proto.Action) bool {
+	if s.after != nil {
+		return !actionAfter(action, s.after)
+	}
+	return action.BlockNum < s.startBlockNum
+}
+
+// removeAction removes action with the same cursor and user,
+// it reports if action was found
+func removeAction(actions []proto.Action, action proto.Action) ([]proto.Action, bool) {
+	for i := range actions {
+		if actions[i].Cursor == action.Cursor && actions[i].UserID == action.UserID {
+			return append(actions[:i], actions[i+1:]...), true
+		}
+	}
+	return actions, false
+}
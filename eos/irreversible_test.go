@@ -0,0 +1,98 @@
+/*
+ * Copyright 2018 Idealnaya rabota LLC
+ * Licensed under Multy.io license.
+ * See LICENSE for details
+ */
+
+package eos
+
+import (
+	"context"
+	"testing"
+
+	"github.com/Multy-io/Multy-EOS-node-service/proto"
+	"google.golang.org/grpc"
+)
+
+// fakeNewTxStream keeps sent actions
+type fakeNewTxStream struct {
+	grpc.ServerStream
+	ctx  context.Context
+	sent []proto.Action
+}
+
+func (stream *fakeNewTxStream) Context() context.Context {
+	return stream.ctx
+}
+
+func (stream *fakeNewTxStream) Send(action *proto.Action) error {
+	stream.sent = append(stream.sent, *action)
+	return nil
+}
+
+func testTxStream(irreversibleOnly bool, policy SlowConsumerPolicy, lib uint32) (*txStream, *fakeNewTxStream) {
+	stream := &fakeNewTxStream{ctx: context.Background()}
+	tracker := &libTracker{lastIrreversibleBlockNum: lib}
+	txs := newTxStream(stream, tracker, irreversibleOnly, policy, 0, nil)
+	txs.maxPending = 2
+	return txs, stream
+}
+
+func TestTxStreamHeldActionsLimit(t *testing.T) {
+	txs, stream := testTxStream(true, PolicyDropOldest, 5)
+	for num := uint32(10); num < 13; num++ {
+		if err := txs.Send(proto.Action{BlockNum: num, Cursor: cursor{blockNum: num}.String()}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if len(txs.pending) != 2 || txs.pending[0].BlockNum != 11 {
+		t.Fatalf("held %v, want actions of blocks 11 and 12", txs.pending)
+	}
+	txs.lib.lastIrreversibleBlockNum = 20
+	if err := txs.Flush(); err != nil {
+		t.Fatal(err)
+	}
+	if len(stream.sent) != 2 || stream.sent[0].Status != proto.Action_IRREVERSIBLE {
+		t.Errorf("sent %v, want 2 irreversible actions", stream.sent)
+	}
+
+	txs, _ = testTxStream(true, PolicyDisconnect, 5)
+	var err error
+	for num := uint32(10); num < 13 && err == nil; num++ {
+		err = txs.Send(proto.Action{BlockNum: num})
+	}
+	if err == nil {
+		t.Error("stream holding too many actions is not disconnected")
+	}
+}
+
+func TestTxStreamRevertsSentActionsOnly(t *testing.T) {
+	txs, stream := testTxStream(false, PolicyBlock, 5)
+	sent := proto.Action{BlockNum: 10, Cursor: cursor{blockNum: 10, account: "alice"}.String()}
+	if err := txs.Send(sent); err != nil {
+		t.Fatal(err)
+	}
+	notSent := proto.Action{BlockNum: 10, Cursor: cursor{blockNum: 10, account: "bob"}.String()}
+	for _, action := range []proto.Action{notSent, sent, sent} {
+		action.Status = proto.Action_REVERTED
+		if err := txs.Send(action); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if len(stream.sent) != 2 || stream.sent[1].Status != proto.Action_REVERTED || stream.sent[1].Cursor != sent.Cursor {
+		t.Errorf("sent %v, want pending and reverted action of alice", stream.sent)
+	}
+
+	// client resumed from block 10 may have got actions before it
+	txs, stream = testTxStream(false, PolicyBlock, 5)
+	txs.startBlockNum = 10
+	for _, num := range []uint32{9, 10} {
+		action := proto.Action{BlockNum: num, Cursor: cursor{blockNum: num}.String(), Status: proto.Action_REVERTED}
+		if err := txs.Send(action); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if len(stream.sent) != 1 || stream.sent[0].BlockNum != 9 {
+		t.Errorf("sent %v, want reverted action of block 9", stream.sent)
+	}
+}
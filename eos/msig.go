@@ -0,0 +1,236 @@
+/*
+ * Copyright 2018 Idealnaya rabota LLC
+ * Licensed under Multy.io license.
+ * See LICENSE for details
+ */
+
+package eos
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"time"
+
+	"github.com/Multy-io/Multy-EOS-node-service/proto"
+	"github.com/eoscanada/eos-go"
+)
+
+// msigProposal is a transaction proposed with eosio.msig
+type msigProposal struct {
+	// requested are requested approvals, nil if proposal is got from table
+	requested []eos.PermissionLevel
+	trx       *eos.Transaction
+}
+
+// msigProposals keeps proposals until they are executed, cancelled
+// or expired. It belongs to single block handler,
+// so it isn't concurrency-safe
+type msigProposals struct {
+	proposals map[string]*msigProposal
+}
+
+func newMsigProposals() *msigProposals {
+	return &msigProposals{
+		proposals: make(map[string]*msigProposal),
+	}
+}
+
+func proposalKey(proposer eos.AccountName, name eos.Name) string {
+	return fmt.Sprintf("%s/%s", proposer, name)
+}
+
+// Add keeps proposal, proposal with the same name is replaced
+func (msigs *msigProposals) Add(proposer eos.AccountName, name eos.Name, proposal *msigProposal) {
+	if msigs == nil {
+		return
+	}
+	msigs.proposals[proposalKey(proposer, name)] = proposal
+}
+
+// Get gets proposal, nil if it's unknown
+func (msigs *msigProposals) Get(proposer eos.AccountName, name eos.Name) *msigProposal {
+	if msigs == nil {
+		return nil
+	}
+	return msigs.proposals[proposalKey(proposer, name)]
+}
+
+// Remove forgets proposal
+func (msigs *msigProposals) Remove(proposer eos.AccountName, name eos.Name) {
+	if msigs == nil {
+		return
+	}
+	delete(msigs.proposals, proposalKey(proposer, name))
+}
+
+// Prune forgets proposals expired before block time
+func (msigs *msigProposals) Prune(blockTime time.Time) {
+	if msigs == nil {
+		return
+	}
+	for key, proposal := range msigs.proposals {
+		if proposal.trx == nil || proposal.trx.Expiration.Time.Before(blockTime) {
+			delete(msigs.proposals, key)
+		}
+	}
+}
+
+// proposalRequestTimeout limits get_table_rows request,
+// so slow node doesn't stall block processing
+const proposalRequestTimeout = 5 * time.Second
+
+// proposalTable gets proposed transactions from eosio.msig proposal table
+type proposalTable struct {
+	rpcAddr string
+	client  *http.Client
+}
+
+func newProposalTable(rpcAddr string) *proposalTable {
+	return &proposalTable{
+		rpcAddr: rpcAddr,
+		client:  &http.Client{Timeout: proposalRequestTimeout},
+	}
+}
+
+// Get gets proposed transaction, it's raw request
+// as table keeps transaction packed
+func (table *proposalTable) Get(proposer eos.AccountName, name eos.Name) (*eos.Transaction, error) {
+	reqJSON, err := json.Marshal(map[string]interface{}{
+		"code":        "eosio.msig",
+		"scope":       proposer,
+		"table":       "proposal",
+		"json":        true,
+		"lower_bound": name,
+		"limit":       1,
+	})
+	if err != nil {
+		return nil, err
+	}
+	resp, err := table.client.Post(fmt.Sprintf("%s/v1/chain/get_table_rows", table.rpcAddr),
+		"application/json", bytes.NewReader(reqJSON))
+	if err != nil {
+		return nil, fmt.Errorf("get_table_rows: %s", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		bs, _ := ioutil.ReadAll(resp.Body)
+		return nil, fmt.Errorf("get_table_rows: response not ok: %v", string(bs))
+	}
+
+	var rows struct {
+		Rows []struct {
+			ProposalName      eos.Name     `json:"proposal_name"`
+			PackedTransaction eos.HexBytes `json:"packed_transaction"`
+		} `json:"rows"`
+	}
+	err = json.NewDecoder(resp.Body).Decode(&rows)
+	if err != nil {
+		return nil, fmt.Errorf("get_table_rows: %s", err)
+	}
+	if len(rows.Rows) == 0 || rows.Rows[0].ProposalName != name {
+		return nil, fmt.Errorf("proposal %s is not found", proposalKey(proposer, name))
+	}
+	trx := &eos.Transaction{}
+	err = eos.UnmarshalBinary(rows.Rows[0].PackedTransaction, trx)
+	if err != nil {
+		return nil, fmt.Errorf("proposal %s: %s", proposalKey(proposer, name), err)
+	}
+	return trx, nil
+}
+
+// proposal gets proposal seen by handler or from msig table if fromTable
+// is set and proposer or actor is tracked, nil if it's not found
+func (handler *blockDataHandler) proposal(users usersView, proposer eos.AccountName, name eos.Name,
+	actor eos.AccountName, fromTable bool) *msigProposal {
+	if proposal := handler.proposals.Get(proposer, name); proposal != nil {
+		return proposal
+	}
+	if !fromTable || handler.msigTable == nil {
+		return nil
+	}
+	_, proposerTracked := users.Get(string(proposer))
+	_, actorTracked := users.Get(string(actor))
+	if !proposerTracked && !actorTracked {
+		return nil
+	}
+	trx, err := handler.msigTable.Get(proposer, name)
+	if err != nil {
+		log.Debugf("proposal: %s (%s)", err, handler.name)
+		return nil
+	}
+	return &msigProposal{trx: trx}
+}
+
+// sendProposal sends msig action to the proposer, the actor,
+// requested approvers and accounts affected by proposed actions
+func (handler *blockDataHandler) sendProposal(users usersView, toSend proto.Action, pos *cursor,
+	proposer eos.AccountName, name eos.Name, actor eos.AccountName, proposal *msigProposal) {
+	var accounts []eos.AccountName
+	seen := make(map[eos.AccountName]struct{})
+	addAccount := func(account eos.AccountName) {
+		if _, ok := seen[account]; ok {
+			return
+		}
+		seen[account] = struct{}{}
+		if _, ok := users.Get(string(account)); ok {
+			accounts = append(accounts, account)
+		}
+	}
+	addAccount(proposer)
+	addAccount(actor)
+
+	toSend.From = string(actor)
+	toSend.To = string(proposer)
+	toSend.Proposal.Proposer = string(proposer)
+	toSend.Proposal.ProposalName = string(name)
+	if proposal != nil {
+		for _, level := range proposal.requested {
+			addAccount(level.Actor)
+			toSend.Proposal.Requested = append(toSend.Proposal.Requested, permissionLevel(level))
+		}
+		if proposal.trx != nil {
+			for _, action := range proposal.trx.Actions {
+				proposed, names := handler.proposedAction(action)
+				toSend.Proposal.Actions = append(toSend.Proposal.Actions, proposed)
+				for _, auth := range action.Authorization {
+					addAccount(auth.Actor)
+				}
+				for _, named := range names {
+					addAccount(eos.AccountName(named))
+				}
+			}
+		}
+	}
+	for _, account := range accounts {
+		handler.sendHistory(users, toSend, pos, account)
+	}
+}
+
+// proposedAction converts proposed action with data decoded
+// by contract ABI or by eos-go, values of name fields are returned as well
+func (handler *blockDataHandler) proposedAction(action *eos.Action) (*proto.ProposedAction, []string) {
+	proposed := &proto.ProposedAction{
+		Contract: string(action.Account),
+		Name:     string(action.Name),
+	}
+	for _, auth := range action.Authorization {
+		proposed.Authorization = append(proposed.Authorization, permissionLevel(auth))
+	}
+	data, names, err := handler.decodeWithABI(action)
+	if err != nil {
+		log.Debugf("proposedAction: %s (%s)", err, handler.name)
+		data = action.Data
+	}
+	if data != nil {
+		dataJSON, err := json.Marshal(data)
+		if err != nil {
+			log.Errorf("proposedAction: %s::%s data: %s", action.Account, action.Name, err)
+		} else {
+			proposed.Data = string(dataJSON)
+		}
+	}
+	return proposed, names
+}
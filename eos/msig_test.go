@@ -0,0 +1,205 @@
+/*
+ * Copyright 2018 Idealnaya rabota LLC
+ * Licensed under Multy.io license.
+ * See LICENSE for details
+ */
+
+package eos
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+
+	"github.com/Multy-io/Multy-EOS-node-service/proto"
+	"github.com/eoscanada/eos-go"
+	"github.com/eoscanada/eos-go/msig"
+	"github.com/eoscanada/eos-go/token"
+)
+
+// proposalServer serves get_table_rows with any requested proposal
+func proposalServer(t *testing.T, requests *int) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		*requests++
+		var req struct {
+			LowerBound string `json:"lower_bound"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			t.Error(err)
+		}
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"rows": []map[string]interface{}{
+				{"proposal_name": req.LowerBound, "packed_transaction": ""},
+			},
+		})
+	}))
+}
+
+func msigAction(name eos.ActionName, data interface{}) *eos.Action {
+	return &eos.Action{
+		Account:    "eosio.msig",
+		Name:       name,
+		ActionData: eos.ActionData{Data: data},
+	}
+}
+
+func TestMapMsigProposalLifecycle(t *testing.T) {
+	requests := 0
+	node := proposalServer(t, &requests)
+	defer node.Close()
+
+	transfer := &token.Transfer{From: "carol", To: "dave", Memo: "salary"}
+	trx := &eos.Transaction{Actions: []*eos.Action{{
+		Account:       "eosio.token",
+		Name:          "transfer",
+		Authorization: []eos.PermissionLevel{{Actor: "carol", Permission: "active"}},
+		ActionData:    eos.ActionData{Data: transfer},
+	}}}
+	transferJSON, err := json.Marshal(transfer)
+	if err != nil {
+		t.Fatal(err)
+	}
+	requested := []*proto.PermissionLevel{{Actor: "bob", Permission: "active"}}
+	proposed := []*proto.ProposedAction{{
+		Contract:      "eosio.token",
+		Name:          "transfer",
+		Authorization: []*proto.PermissionLevel{{Actor: "carol", Permission: "active"}},
+		Data:          string(transferJSON),
+	}}
+
+	handler := &blockDataHandler{
+		proposals: newMsigProposals(),
+		msigTable: newProposalTable(node.URL),
+	}
+	// proposer alice isn't tracked, requested bob and affected carol are
+	sent := mapActions(handler, testUsers("bob", "carol"),
+		msigAction("propose", &msig.Propose{
+			Proposer:     "alice",
+			ProposalName: "pay",
+			Requested:    []eos.PermissionLevel{{Actor: "bob", Permission: "active"}},
+			Transaction:  trx,
+		}),
+		msigAction("approve", &msig.Approve{
+			Proposer:     "alice",
+			ProposalName: "pay",
+			Level:        eos.PermissionLevel{Actor: "bob", Permission: "active"},
+		}),
+		msigAction("exec", &msig.Exec{
+			Proposer:     "alice",
+			ProposalName: "pay",
+			Executer:     "alice",
+		}),
+	)
+
+	proposal := func(level *proto.PermissionLevel) *proto.Proposal {
+		return &proto.Proposal{
+			Proposer:     "alice",
+			ProposalName: "pay",
+			Requested:    requested,
+			Actions:      proposed,
+			Level:        level,
+		}
+	}
+	msigSent := func(actionType proto.Action_Type, from string, level *proto.PermissionLevel, to string) proto.Action {
+		return proto.Action{
+			Type:     actionType,
+			Contract: "eosio.msig",
+			From:     from,
+			To:       "alice",
+			Address:  to,
+			Proposal: proposal(level),
+		}
+	}
+	bobActive := &proto.PermissionLevel{Actor: "bob", Permission: "active"}
+	want := []proto.Action{
+		msigSent(proto.Action_MSIG_PROPOSE, "alice", nil, "bob"),
+		msigSent(proto.Action_MSIG_PROPOSE, "alice", nil, "carol"),
+		msigSent(proto.Action_MSIG_APPROVE, "bob", bobActive, "bob"),
+		msigSent(proto.Action_MSIG_APPROVE, "bob", bobActive, "carol"),
+		msigSent(proto.Action_MSIG_EXEC, "alice", nil, "bob"),
+		msigSent(proto.Action_MSIG_EXEC, "alice", nil, "carol"),
+	}
+	if !reflect.DeepEqual(sent, want) {
+		t.Errorf("sent %+v,\nwant %+v", sent, want)
+	}
+	if requests != 0 {
+		t.Errorf("%d proposal table requests for proposal seen", requests)
+	}
+	if handler.proposals.Get("alice", "pay") != nil {
+		t.Error("executed proposal is kept")
+	}
+}
+
+func TestMapMsigUnknownProposal(t *testing.T) {
+	level := eos.PermissionLevel{Actor: "bob", Permission: "active"}
+	tests := []struct {
+		name   string
+		users  []string
+		action *eos.Action
+		// requests are proposal table requests made
+		requests int
+		to       []string
+	}{
+		{
+			name:     "approve of tracked proposer",
+			users:    []string{"alice"},
+			action:   msigAction("approve", &msig.Approve{Proposer: "alice", ProposalName: "pay", Level: level}),
+			requests: 1,
+			to:       []string{"alice"},
+		},
+		{
+			name:     "approve by tracked actor",
+			users:    []string{"bob"},
+			action:   msigAction("approve", &msig.Approve{Proposer: "alice", ProposalName: "pay", Level: level}),
+			requests: 1,
+			to:       []string{"bob"},
+		},
+		{
+			name:   "approve untracked",
+			users:  []string{"carol"},
+			action: msigAction("approve", &msig.Approve{Proposer: "alice", ProposalName: "pay", Level: level}),
+		},
+		{
+			name:     "unapprove by tracked actor",
+			users:    []string{"bob"},
+			action:   msigAction("unapprove", &msig.Unapprove{Proposer: "alice", ProposalName: "pay", Level: level}),
+			requests: 1,
+			to:       []string{"bob"},
+		},
+		{
+			name:   "cancel of tracked proposer",
+			users:  []string{"alice"},
+			action: msigAction("cancel", &msig.Cancel{Proposer: "alice", ProposalName: "pay", Canceler: "alice"}),
+			to:     []string{"alice"},
+		},
+		{
+			name:   "exec by tracked executer",
+			users:  []string{"bob"},
+			action: msigAction("exec", &msig.Exec{Proposer: "alice", ProposalName: "pay", Executer: "bob"}),
+			to:     []string{"bob"},
+		},
+	}
+	for _, test := range tests {
+		requests := 0
+		node := proposalServer(t, &requests)
+		handler := &blockDataHandler{
+			proposals: newMsigProposals(),
+			msigTable: newProposalTable(node.URL),
+		}
+		sent := mapActions(handler, testUsers(test.users...), test.action)
+		node.Close()
+
+		if requests != test.requests {
+			t.Errorf("%s: %d proposal table requests, want %d", test.name, requests, test.requests)
+		}
+		var to []string
+		for _, action := range sent {
+			to = append(to, action.Address)
+		}
+		if !reflect.DeepEqual(to, test.to) {
+			t.Errorf("%s: sent to %v, want %v", test.name, to, test.to)
+		}
+	}
+}
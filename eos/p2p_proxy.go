@@ -0,0 +1,110 @@
+/*
+ * Copyright 2018 Idealnaya rabota LLC
+ * Licensed under Multy.io license.
+ * See LICENSE for details
+ */
+
+package eos
+
+import (
+	"fmt"
+	"io"
+	"net"
+	"sync"
+	"time"
+)
+
+// p2pDialTimeout is a timeout of connecting to p2p node
+const p2pDialTimeout = 10 * time.Second
+
+// p2pProxy forwards local connections to p2p node.
+// eos-go p2p client has no Close, so client connects through proxy
+// and proxy closes connection making client's sync return
+type p2pProxy struct {
+	listener net.Listener
+	nodeAddr string
+
+	mu     sync.Mutex
+	conns  []net.Conn
+	closed bool
+}
+
+// newP2PProxy listens on local port for connections to nodeAddr
+func newP2PProxy(nodeAddr string) (*p2pProxy, error) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		return nil, fmt.Errorf("p2p proxy: %s", err)
+	}
+	proxy := &p2pProxy{
+		listener: listener,
+		nodeAddr: nodeAddr,
+	}
+	go proxy.accept()
+	return proxy, nil
+}
+
+// Addr gets local address to connect to
+func (proxy *p2pProxy) Addr() string {
+	return proxy.listener.Addr().String()
+}
+
+// Close stops accepting connections and closes forwarded ones
+func (proxy *p2pProxy) Close() error {
+	proxy.mu.Lock()
+	if proxy.closed {
+		proxy.mu.Unlock()
+		return nil
+	}
+	proxy.closed = true
+	conns := proxy.conns
+	proxy.conns = nil
+	proxy.mu.Unlock()
+
+	err := proxy.listener.Close()
+	for _, conn := range conns {
+		conn.Close()
+	}
+	return err
+}
+
+func (proxy *p2pProxy) accept() {
+	for {
+		conn, err := proxy.listener.Accept()
+		if err != nil {
+			return
+		}
+		node, err := net.DialTimeout("tcp", proxy.nodeAddr, p2pDialTimeout)
+		if err != nil {
+			log.Errorf("p2p proxy: %s", err)
+			conn.Close()
+			continue
+		}
+		if !proxy.track(conn, node) {
+			return
+		}
+		go pipe(conn, node)
+		go pipe(node, conn)
+	}
+}
+
+// track keeps connections to close them on Close,
+// they are closed at once if proxy is already closed
+func (proxy *p2pProxy) track(conns ...net.Conn) bool {
+	proxy.mu.Lock()
+	defer proxy.mu.Unlock()
+	if proxy.closed {
+		for _, conn := range conns {
+			conn.Close()
+		}
+		return false
+	}
+	proxy.conns = append(proxy.conns, conns...)
+	return true
+}
+
+// pipe copies src to dst, both are closed when either side is done
+func pipe(dst, src net.Conn) {
+	io.Copy(dst, src)
+	dst.Close()
+	src.Close()
+}
@@ -0,0 +1,141 @@
+/*
+ * Copyright 2018 Idealnaya rabota LLC
+ * Licensed under Multy.io license.
+ * See LICENSE for details
+ */
+
+package eos
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"sort"
+	"time"
+
+	"github.com/Multy-io/Multy-EOS-node-service/proto"
+	"github.com/eoscanada/eos-go"
+)
+
+// startResync schedules resync job of tracked account,
+// it may be merged with existing job for the account
+func (server *Server) startResync(acc *proto.AddressToResync) (*resyncJob, error) {
+	// check if account is in trackedUsers
+	users, ok := server.trackedUsers.Get(acc.Address)
+	if !ok {
+		return nil, fmt.Errorf("user not trackedUsers: %s", acc.Address)
+	}
+
+	startBlockNum, endBlockNum, err := server.resyncRange(acc)
+	if err != nil {
+		return nil, err
+	}
+
+	ctx, cancel := context.WithCancel(server.ctx)
+	job := &resyncJob{
+		address:       acc.Address,
+		ctx:           ctx,
+		cancel:        cancel,
+		done:          make(chan struct{}),
+		startBlockNum: startBlockNum,
+		endBlockNum:   endBlockNum,
+		status:        proto.ResyncProgress_QUEUED,
+		source:        acc.Source,
+		useHistory:    acc.Source != proto.AddressToResync_P2P,
+		handler: server.newBlockHandler(ctx, fmt.Sprintf("resync %s", acc.Address),
+			newTrackedUsers(map[string][]UserData{acc.Address: users}), server.historyCh),
+	}
+	job.handler.resync = true
+	job = server.resyncScheduler.Schedule(job)
+	log.Debugf("resync %s: job %s blocks %d-%d", acc.Address, job.id, startBlockNum, endBlockNum)
+	return job, nil
+}
+
+// resyncRange gets blocks range to resync account in
+func (server *Server) resyncRange(acc *proto.AddressToResync) (uint32, uint32, error) {
+	info, err := server.api.GetInfo()
+	if err != nil {
+		return 0, 0, fmt.Errorf("get_info: %s", err)
+	}
+
+	endBlockNum := acc.EndBlock
+	if endBlockNum == 0 || endBlockNum > info.HeadBlockNum {
+		endBlockNum = info.HeadBlockNum
+	}
+	startBlockNum := acc.StartBlock
+	if acc.FromCreation {
+		startBlockNum, err = server.accountCreationBlock(acc.Address, info.HeadBlockNum)
+		if err != nil {
+			return 0, 0, err
+		}
+	}
+	if startBlockNum == 0 {
+		startBlockNum = 1
+	}
+	if startBlockNum > endBlockNum {
+		return 0, 0, fmt.Errorf("start block %d is after end block %d", startBlockNum, endBlockNum)
+	}
+	return startBlockNum, endBlockNum, nil
+}
+
+// accountCreationBlock looks for the block account was created in
+// using binary search by block time
+func (server *Server) accountCreationBlock(account string, headBlockNum uint32) (uint32, error) {
+	created, err := server.accountCreated(account)
+	if err != nil {
+		return 0, err
+	}
+
+	var searchErr error
+	idx := sort.Search(int(headBlockNum), func(i int) bool {
+		if searchErr != nil {
+			return true
+		}
+		block, err := server.api.GetBlockByNum(uint32(i + 1))
+		if err != nil {
+			searchErr = fmt.Errorf("get_block %d: %s", i+1, err)
+			return true
+		}
+		return !block.Timestamp.Time.Before(created)
+	})
+	if searchErr != nil {
+		return 0, searchErr
+	}
+	if idx == int(headBlockNum) {
+		return 0, fmt.Errorf("account %s is created after head block", account)
+	}
+	return uint32(idx + 1), nil
+}
+
+// accountCreated gets account creation time.
+// eos-go account response misses it, so it's raw request
+func (server *Server) accountCreated(account string) (time.Time, error) {
+	reqJSON, err := json.Marshal(map[string]string{
+		"account_name": account,
+	})
+	if err != nil {
+		return time.Time{}, err
+	}
+	resp, err := server.rpcClient.Post(fmt.Sprintf("%s/v1/chain/get_account", server.rpcAddr),
+		"application/json", bytes.NewReader(reqJSON))
+	if err != nil {
+		return time.Time{}, fmt.Errorf("get_account: %s", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		bs, _ := ioutil.ReadAll(resp.Body)
+		return time.Time{}, fmt.Errorf("get_account: response not ok: %v", string(bs))
+	}
+
+	var accountResp struct {
+		Created eos.JSONTime `json:"created"`
+	}
+	err = json.NewDecoder(resp.Body).Decode(&accountResp)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("get_account: %s", err)
+	}
+	return accountResp.Created.Time, nil
+}
@@ -0,0 +1,210 @@
+/*
+ * Copyright 2018 Idealnaya rabota LLC
+ * Licensed under Multy.io license.
+ * See LICENSE for details
+ */
+
+package eos
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/Multy-io/Multy-EOS-node-service/proto"
+	"github.com/eoscanada/eos-go"
+)
+
+const (
+	// resyncProgressInterval is an interval of progress streaming
+	resyncProgressInterval = 5 * time.Second
+	// finishedJobTTL is how long finished job can be queried
+	finishedJobTTL = time.Hour
+)
+
+// resyncJob is a single account resync
+type resyncJob struct {
+	id      string
+	address string
+
+	ctx    context.Context
+	cancel context.CancelFunc
+	// done is closed when job is finished
+	done       chan struct{}
+	finishOnce sync.Once
+
+	startBlockNum   uint32
+	endBlockNum     uint32
+	currentBlockNum uint32
+
+	source proto.AddressToResync_Source
+	// useHistory is set while history api is not known to be unavailable
+	useHistory bool
+
+	handler *blockDataHandler
+
+	mu     sync.Mutex
+	status proto.ResyncProgress_Status
+	err    error
+}
+
+// HandleBlock tracks job progress
+func (job *resyncJob) HandleBlock(block *eos.SignedBlock) {
+	job.handler.HandleBlock(block)
+	job.setCurrentBlockNum(block.BlockNumber())
+}
+
+func (job *resyncJob) setCurrentBlockNum(blockNum uint32) {
+	atomic.StoreUint32(&job.currentBlockNum, blockNum)
+}
+
+// Done is closed when job is finished
+func (job *resyncJob) Done() <-chan struct{} {
+	return job.done
+}
+
+// Cancel stops job
+func (job *resyncJob) Cancel() {
+	job.cancel()
+}
+
+// Progress gets current job state
+func (job *resyncJob) Progress() *proto.ResyncProgress {
+	job.mu.Lock()
+	defer job.mu.Unlock()
+	progress := &proto.ResyncProgress{
+		JobID:        job.id,
+		Address:      job.address,
+		Status:       job.status,
+		StartBlock:   job.startBlockNum,
+		CurrentBlock: atomic.LoadUint32(&job.currentBlockNum),
+		EndBlock:     job.endBlockNum,
+		ActionsFound: atomic.LoadUint64(&job.handler.actionsSent),
+	}
+	if job.err != nil {
+		progress.Error = job.err.Error()
+	}
+	return progress
+}
+
+// start marks queued job as running
+func (job *resyncJob) start() {
+	job.mu.Lock()
+	defer job.mu.Unlock()
+	job.status = proto.ResyncProgress_RUNNING
+}
+
+// merge extends job's range with the given one of the same source
+// if ranges overlap or are adjacent, it reports if range is merged
+func (job *resyncJob) merge(startBlockNum, endBlockNum uint32, source proto.AddressToResync_Source) bool {
+	job.mu.Lock()
+	defer job.mu.Unlock()
+	if source != job.source ||
+		uint64(startBlockNum) > uint64(job.endBlockNum)+1 || uint64(endBlockNum)+1 < uint64(job.startBlockNum) {
+		return false
+	}
+	if startBlockNum < job.startBlockNum {
+		job.startBlockNum = startBlockNum
+	}
+	if endBlockNum > job.endBlockNum {
+		job.endBlockNum = endBlockNum
+	}
+	return true
+}
+
+// covers reports if job's blocks range includes the given one
+func (job *resyncJob) covers(startBlockNum, endBlockNum uint32) bool {
+	job.mu.Lock()
+	defer job.mu.Unlock()
+	return job.startBlockNum <= startBlockNum && endBlockNum <= job.endBlockNum
+}
+
+// finish sets job's final status by error of the blocks scan,
+// only the first call matters
+func (job *resyncJob) finish(err error) {
+	job.finishOnce.Do(func() {
+		job.setResult(err)
+		job.cancel()
+		close(job.done)
+	})
+}
+
+func (job *resyncJob) setResult(err error) {
+	job.mu.Lock()
+	defer job.mu.Unlock()
+	switch {
+	case err == nil:
+		job.status = proto.ResyncProgress_DONE
+	case err == context.Canceled:
+		job.status = proto.ResyncProgress_CANCELLED
+	case err == context.DeadlineExceeded:
+		job.status = proto.ResyncProgress_FAILED
+		job.err = fmt.Errorf("timeout")
+	default:
+		job.status = proto.ResyncProgress_FAILED
+		job.err = err
+	}
+}
+
+// resyncJobs keeps running and recently finished jobs
+type resyncJobs struct {
+	mu     sync.Mutex
+	jobs   map[string]*resyncJob
+	nextID uint64
+}
+
+func newResyncJobs() *resyncJobs {
+	return &resyncJobs{
+		jobs: make(map[string]*resyncJob),
+	}
+}
+
+// Add registers job assigning its id
+func (jobs *resyncJobs) Add(job *resyncJob) {
+	jobs.mu.Lock()
+	defer jobs.mu.Unlock()
+	jobs.nextID++
+	job.id = strconv.FormatUint(jobs.nextID, 10)
+	jobs.jobs[job.id] = job
+}
+
+// ForgetLater removes finished job in finishedJobTTL
+func (jobs *resyncJobs) ForgetLater(job *resyncJob) {
+	time.AfterFunc(finishedJobTTL, func() {
+		jobs.mu.Lock()
+		defer jobs.mu.Unlock()
+		delete(jobs.jobs, job.id)
+	})
+}
+
+// Get gets job by id
+func (jobs *resyncJobs) Get(id string) (*resyncJob, bool) {
+	jobs.mu.Lock()
+	defer jobs.mu.Unlock()
+	job, ok := jobs.jobs[id]
+	return job, ok
+}
+
+// List gets progress of all the jobs ordered by id
+func (jobs *resyncJobs) List() []*proto.ResyncProgress {
+	jobs.mu.Lock()
+	list := make([]*resyncJob, 0, len(jobs.jobs))
+	for _, job := range jobs.jobs {
+		list = append(list, job)
+	}
+	jobs.mu.Unlock()
+
+	sort.Slice(list, func(i, j int) bool {
+		return len(list[i].id) < len(list[j].id) ||
+			len(list[i].id) == len(list[j].id) && list[i].id < list[j].id
+	})
+	progress := make([]*proto.ResyncProgress, len(list))
+	for i, job := range list {
+		progress[i] = job.Progress()
+	}
+	return progress
+}
@@ -0,0 +1,149 @@
+/*
+ * Copyright 2018 Idealnaya rabota LLC
+ * Licensed under Multy.io license.
+ * See LICENSE for details
+ */
+
+package eos
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/Multy-io/Multy-EOS-node-service/proto"
+)
+
+// runningJob makes running resync job of alice without tracked users
+func runningJob(start, end uint32) *resyncJob {
+	ctx, cancel := context.WithCancel(context.Background())
+	return &resyncJob{
+		address:       "alice",
+		ctx:           ctx,
+		cancel:        cancel,
+		done:          make(chan struct{}),
+		startBlockNum: start,
+		endBlockNum:   end,
+		status:        proto.ResyncProgress_RUNNING,
+		handler: &blockDataHandler{
+			ctx:          ctx,
+			resync:       true,
+			history:      make(chan proto.Action, 1),
+			trackedUsers: newTrackedUsers(nil),
+		},
+	}
+}
+
+func TestResyncJobProgress(t *testing.T) {
+	jobs := newResyncJobs()
+	job := runningJob(10, 20)
+	jobs.Add(job)
+	for num := uint32(10); num <= 15; num++ {
+		job.HandleBlock(testBlock(num))
+	}
+	job.handler.actionsSent = 3
+
+	want := &proto.ResyncProgress{
+		JobID:        job.id,
+		Address:      "alice",
+		Status:       proto.ResyncProgress_RUNNING,
+		StartBlock:   10,
+		CurrentBlock: 15,
+		EndBlock:     20,
+		ActionsFound: 3,
+	}
+	if got := job.Progress(); !reflect.DeepEqual(got, want) {
+		t.Errorf("progress %+v, want %+v", got, want)
+	}
+}
+
+func TestResyncJobFinish(t *testing.T) {
+	tests := []struct {
+		name   string
+		err    error
+		status proto.ResyncProgress_Status
+		msg    string
+	}{
+		{"done", nil, proto.ResyncProgress_DONE, ""},
+		{"cancelled", context.Canceled, proto.ResyncProgress_CANCELLED, ""},
+		{"timeout", context.DeadlineExceeded, proto.ResyncProgress_FAILED, "timeout"},
+		{"failed", errors.New("p2p: connection reset"), proto.ResyncProgress_FAILED, "p2p: connection reset"},
+	}
+	for _, test := range tests {
+		job := runningJob(10, 20)
+		job.finish(test.err)
+		// only the first result matters
+		job.finish(errors.New("late error"))
+
+		select {
+		case <-job.Done():
+		default:
+			t.Errorf("%s: job is not done", test.name)
+		}
+		if job.ctx.Err() == nil {
+			t.Errorf("%s: job context is not cancelled", test.name)
+		}
+		progress := job.Progress()
+		if progress.Status != test.status || progress.Error != test.msg {
+			t.Errorf("%s: status %s (%q), want %s (%q)", test.name,
+				progress.Status, progress.Error, test.status, test.msg)
+		}
+	}
+}
+
+func TestResyncJobsRPC(t *testing.T) {
+	ctx := context.Background()
+	server := &Server{resyncJobs: newResyncJobs()}
+	server.resyncScheduler = newResyncScheduler(nil, nil, server.resyncJobs)
+	var added []*resyncJob
+	for i := 0; i < 11; i++ {
+		job := runningJob(uint32(i+1), 100)
+		server.resyncJobs.Add(job)
+		added = append(added, job)
+	}
+
+	list, err := server.ResyncJobs(ctx, &proto.Empty{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(list.Jobs) != len(added) {
+		t.Fatalf("%d jobs listed, want %d", len(list.Jobs), len(added))
+	}
+	for i, progress := range list.Jobs {
+		// ids are ordered as numbers, so job 10 goes after job 9
+		if progress.JobID != added[i].id || progress.StartBlock != uint32(i+1) {
+			t.Errorf("job %d is %s from block %d", i, progress.JobID, progress.StartBlock)
+		}
+	}
+
+	job := added[9]
+	if _, err := server.CancelResyncJob(ctx, &proto.ResyncJobID{JobID: job.id}); err != nil {
+		t.Fatal(err)
+	}
+	if job.ctx.Err() != context.Canceled {
+		t.Fatalf("job context error is %v, want cancelled", job.ctx.Err())
+	}
+	for _, other := range added {
+		if other != job && other.ctx.Err() != nil {
+			t.Errorf("job %s is cancelled too", other.id)
+		}
+	}
+	// blocks scan stops with context error
+	job.finish(job.ctx.Err())
+	progress, err := server.GetResyncJob(ctx, &proto.ResyncJobID{JobID: job.id})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if progress.Status != proto.ResyncProgress_CANCELLED {
+		t.Errorf("cancelled job status is %s", progress.Status)
+	}
+
+	if _, err := server.GetResyncJob(ctx, &proto.ResyncJobID{JobID: "100"}); err == nil {
+		t.Error("unknown job is got")
+	}
+	reply, err := server.CancelResyncJob(ctx, &proto.ResyncJobID{JobID: "100"})
+	if err == nil || reply.Message == "" {
+		t.Error("unknown job cancel error is not reported")
+	}
+}
@@ -0,0 +1,287 @@
+/*
+ * Copyright 2018 Idealnaya rabota LLC
+ * Licensed under Multy.io license.
+ * See LICENSE for details
+ */
+
+package eos
+
+import (
+	"context"
+	"sync"
+
+	"github.com/Multy-io/Multy-EOS-node-service/proto"
+	"github.com/eoscanada/eos-go"
+)
+
+const (
+	// defaultResyncWorkers is a default number of concurrent block scans
+	defaultResyncWorkers = 2
+	// defaultResyncBatchSize is a default number of jobs in one block scan
+	defaultResyncBatchSize = 10
+)
+
+// resyncScheduler queues resync jobs and runs them by limited number
+// of workers. Every worker scans blocks once for a batch of jobs
+// or gets single job's actions from history api
+type resyncScheduler struct {
+	ingestion *blockIngestion
+	history   *historyAPI
+	jobs      *resyncJobs
+
+	mu        sync.Mutex
+	workers   int
+	batchSize int
+	queue     []*resyncJob
+	// active jobs are the ones being scanned
+	active map[*resyncJob]struct{}
+	// wake wakes up idle worker
+	wake chan struct{}
+}
+
+func newResyncScheduler(ingestion *blockIngestion, history *historyAPI, jobs *resyncJobs) *resyncScheduler {
+	return &resyncScheduler{
+		ingestion: ingestion,
+		history:   history,
+		jobs:      jobs,
+		workers:   defaultResyncWorkers,
+		batchSize: defaultResyncBatchSize,
+		active:    make(map[*resyncJob]struct{}),
+		wake:      make(chan struct{}, 1),
+	}
+}
+
+// SetLimits sets number of workers and jobs in a batch,
+// it must be called before Run
+func (s *resyncScheduler) SetLimits(workers, batchSize int) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if workers > 0 {
+		s.workers = workers
+	}
+	if batchSize > 0 {
+		s.batchSize = batchSize
+	}
+}
+
+// Schedule queues job. If there is queued job for the same account and source
+// which range overlaps or is adjacent to job's one, it is extended and returned
+// instead, the same is for active job which covers the range
+func (s *resyncScheduler) Schedule(job *resyncJob) *resyncJob {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, queued := range s.queue {
+		if queued.address == job.address && queued.merge(job.startBlockNum, job.endBlockNum, job.source) {
+			job.cancel()
+			return queued
+		}
+	}
+	for active := range s.active {
+		if active.address == job.address && active.source == job.source &&
+			active.covers(job.startBlockNum, job.endBlockNum) {
+			job.cancel()
+			return active
+		}
+	}
+
+	s.jobs.Add(job)
+	s.queue = append(s.queue, job)
+	s.notify()
+	return job
+}
+
+// Cancel cancels job, queued job is finished at once
+func (s *resyncScheduler) Cancel(job *resyncJob) {
+	s.mu.Lock()
+	for i, queued := range s.queue {
+		if queued == job {
+			s.queue = append(s.queue[:i], s.queue[i+1:]...)
+			s.mu.Unlock()
+			job.finish(context.Canceled)
+			s.jobs.ForgetLater(job)
+			return
+		}
+	}
+	s.mu.Unlock()
+	job.Cancel()
+}
+
+// Run runs workers until ctx is done
+func (s *resyncScheduler) Run(ctx context.Context) {
+	s.mu.Lock()
+	workers := s.workers
+	s.mu.Unlock()
+
+	var wg sync.WaitGroup
+	wg.Add(workers)
+	for i := 0; i < workers; i++ {
+		go func() {
+			defer wg.Done()
+			s.work(ctx)
+		}()
+	}
+	wg.Wait()
+
+	// nobody runs queued jobs anymore
+	s.mu.Lock()
+	queue := s.queue
+	s.queue = nil
+	s.mu.Unlock()
+	for _, job := range queue {
+		job.finish(context.Canceled)
+	}
+}
+
+func (s *resyncScheduler) work(ctx context.Context) {
+	for {
+		batch := s.next()
+		if len(batch) == 0 {
+			select {
+			case <-ctx.Done():
+				return
+			case <-s.wake:
+			}
+			continue
+		}
+		if batch[0].useHistory {
+			s.fetch(batch[0])
+			continue
+		}
+		s.scan(ctx, batch)
+	}
+}
+
+// notify wakes up a worker, s.mu must be held
+func (s *resyncScheduler) notify() {
+	select {
+	case s.wake <- struct{}{}:
+	default:
+	}
+}
+
+// next takes batch of jobs from the queue,
+// history api job is taken alone
+func (s *resyncScheduler) next() []*resyncJob {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	var batch []*resyncJob
+	if len(s.queue) != 0 && s.queue[0].useHistory {
+		batch = s.queue[:1:1]
+		s.queue = s.queue[1:]
+	} else {
+		var rest []*resyncJob
+		for _, job := range s.queue {
+			if len(batch) < s.batchSize && !job.useHistory {
+				batch = append(batch, job)
+			} else {
+				rest = append(rest, job)
+			}
+		}
+		s.queue = rest
+	}
+	for _, job := range batch {
+		s.active[job] = struct{}{}
+	}
+	if len(s.queue) != 0 {
+		s.notify()
+	}
+	return batch
+}
+
+// fetch gets job's actions from history api,
+// job goes back to the queue for blocks scan if api is unavailable
+func (s *resyncScheduler) fetch(job *resyncJob) {
+	job.start()
+	ctx, cancel := context.WithTimeout(job.ctx, resyncTimeout)
+	defer cancel()
+	err := s.fetchHistory(ctx, job)
+
+	s.mu.Lock()
+	delete(s.active, job)
+	if err == errHistoryUnavailable && job.source == proto.AddressToResync_AUTO {
+		log.Warnf("resync %s: %s, fall back to blocks scan", job.address, err)
+		job.useHistory = false
+		s.queue = append([]*resyncJob{job}, s.queue...)
+		s.notify()
+		s.mu.Unlock()
+		return
+	}
+	s.mu.Unlock()
+
+	if jobErr := job.ctx.Err(); jobErr != nil {
+		err = jobErr
+	}
+	job.finish(err)
+	s.jobs.ForgetLater(job)
+}
+
+// scan replays blocks range covering all the batch jobs
+func (s *resyncScheduler) scan(ctx context.Context, jobs []*resyncJob) {
+	startBlockNum, endBlockNum := jobs[0].startBlockNum, jobs[0].endBlockNum
+	for _, job := range jobs {
+		job.start()
+		if job.startBlockNum < startBlockNum {
+			startBlockNum = job.startBlockNum
+		}
+		if job.endBlockNum > endBlockNum {
+			endBlockNum = job.endBlockNum
+		}
+	}
+	log.Debugf("resync scheduler: scan %d jobs, blocks %d-%d", len(jobs), startBlockNum, endBlockNum)
+
+	scanCtx, cancel := context.WithTimeout(ctx, resyncTimeout)
+	defer cancel()
+	batch := &resyncBatch{
+		jobs:   jobs,
+		cancel: cancel,
+	}
+	err := s.ingestion.Replay(scanCtx, startBlockNum, endBlockNum, batch)
+
+	s.mu.Lock()
+	for _, job := range jobs {
+		delete(s.active, job)
+	}
+	s.mu.Unlock()
+	for _, job := range jobs {
+		if jobErr := job.ctx.Err(); jobErr != nil {
+			job.finish(jobErr)
+		} else {
+			job.finish(err)
+		}
+		s.jobs.ForgetLater(job)
+	}
+}
+
+// resyncBatch passes scanned blocks to every job in its range
+type resyncBatch struct {
+	jobs []*resyncJob
+	// cancel stops scan when all the jobs are finished
+	cancel context.CancelFunc
+}
+
+func (batch *resyncBatch) HandleBlock(block *eos.SignedBlock) {
+	num := block.BlockNumber()
+	running := 0
+	for _, job := range batch.jobs {
+		select {
+		case <-job.Done():
+			continue
+		default:
+		}
+		if err := job.ctx.Err(); err != nil {
+			job.finish(err)
+			continue
+		}
+		if num >= job.startBlockNum {
+			job.HandleBlock(block)
+		}
+		if num >= job.endBlockNum {
+			job.finish(nil)
+			continue
+		}
+		running++
+	}
+	if running == 0 {
+		batch.cancel()
+	}
+}
@@ -0,0 +1,228 @@
+/*
+ * Copyright 2018 Idealnaya rabota LLC
+ * Licensed under Multy.io license.
+ * See LICENSE for details
+ */
+
+package eos
+
+import (
+	"context"
+	"errors"
+	"runtime"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/Multy-io/Multy-EOS-node-service/proto"
+	"github.com/eoscanada/eos-go"
+	"github.com/eoscanada/eos-go/p2p"
+)
+
+// fakeSyncer streams empty blocks from startBlockNum until it's closed
+type fakeSyncer struct {
+	mu       sync.Mutex
+	handlers []p2p.Handler
+	next     uint32
+
+	closed    chan struct{}
+	closeOnce sync.Once
+}
+
+func newFakeSyncer(startBlockNum uint32) *fakeSyncer {
+	return &fakeSyncer{
+		next:   startBlockNum,
+		closed: make(chan struct{}),
+	}
+}
+
+func (syncer *fakeSyncer) RegisterHandler(handler p2p.Handler) {
+	syncer.mu.Lock()
+	defer syncer.mu.Unlock()
+	syncer.handlers = append(syncer.handlers, handler)
+}
+
+func (syncer *fakeSyncer) UnregisterHandler(handler p2p.Handler) {
+	syncer.mu.Lock()
+	defer syncer.mu.Unlock()
+	for i, h := range syncer.handlers {
+		if h == handler {
+			syncer.handlers = append(syncer.handlers[:i], syncer.handlers[i+1:]...)
+			return
+		}
+	}
+}
+
+func (syncer *fakeSyncer) Sync() error {
+	for {
+		select {
+		case <-syncer.closed:
+			return errors.New("connection closed")
+		default:
+		}
+		msg := p2p.Message{
+			Envelope: &eos.Packet{
+				Type:       eos.SignedBlockType,
+				P2PMessage: testBlock(syncer.next),
+			},
+		}
+		syncer.next++
+		syncer.mu.Lock()
+		handlers := append([]p2p.Handler(nil), syncer.handlers...)
+		syncer.mu.Unlock()
+		for _, handler := range handlers {
+			handler.Handle(msg)
+		}
+	}
+}
+
+func (syncer *fakeSyncer) Close() error {
+	syncer.closeOnce.Do(func() { close(syncer.closed) })
+	return nil
+}
+
+// waitGoroutines waits for number of goroutines to drop to baseline
+func waitGoroutines(t *testing.T, baseline int) {
+	deadline := time.Now().Add(5 * time.Second)
+	for runtime.NumGoroutine() > baseline {
+		if time.Now().After(deadline) {
+			buf := make([]byte, 1<<16)
+			t.Fatalf("%d goroutines left, baseline is %d:\n%s",
+				runtime.NumGoroutine(), baseline, buf[:runtime.Stack(buf, true)])
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
+
+func TestResyncScanLeavesNoGoroutines(t *testing.T) {
+	baseline := runtime.NumGoroutine()
+
+	var syncers []*fakeSyncer
+	ingestion := newBlockIngestion(nil, "", nil)
+	ingestion.dial = func(startBlockNum uint32) (p2pSyncer, error) {
+		syncer := newFakeSyncer(startBlockNum)
+		syncers = append(syncers, syncer)
+		return syncer, nil
+	}
+	scheduler := newResyncScheduler(ingestion, &historyAPI{}, newResyncJobs())
+
+	ctx, cancel := context.WithCancel(context.Background())
+	stopped := make(chan struct{})
+	go func() {
+		scheduler.Run(ctx)
+		close(stopped)
+	}()
+
+	jobCtx, jobCancel := context.WithCancel(ctx)
+	job := scheduler.Schedule(&resyncJob{
+		address:       "alice",
+		ctx:           jobCtx,
+		cancel:        jobCancel,
+		done:          make(chan struct{}),
+		startBlockNum: 10,
+		endBlockNum:   20,
+		status:        proto.ResyncProgress_QUEUED,
+		source:        proto.AddressToResync_P2P,
+		handler: &blockDataHandler{
+			ctx:          jobCtx,
+			resync:       true,
+			history:      make(chan proto.Action),
+			trackedUsers: newTrackedUsers(nil),
+		},
+	})
+
+	select {
+	case <-job.Done():
+	case <-time.After(5 * time.Second):
+		t.Fatal("job is not finished")
+	}
+	progress := job.Progress()
+	if progress.Status != proto.ResyncProgress_DONE {
+		t.Fatalf("job status is %s (%s), want DONE", progress.Status, progress.Error)
+	}
+	if progress.CurrentBlock != 20 {
+		t.Errorf("job current block is %d, want 20", progress.CurrentBlock)
+	}
+
+	cancel()
+	<-stopped
+	if len(syncers) != 1 {
+		t.Fatalf("%d connections made, want 1", len(syncers))
+	}
+	select {
+	case <-syncers[0].closed:
+	default:
+		t.Error("connection is not closed")
+	}
+	waitGoroutines(t, baseline)
+}
+
+func testResyncJob(start, end uint32, source proto.AddressToResync_Source) *resyncJob {
+	ctx, cancel := context.WithCancel(context.Background())
+	return &resyncJob{
+		address:       "alice",
+		ctx:           ctx,
+		cancel:        cancel,
+		done:          make(chan struct{}),
+		startBlockNum: start,
+		endBlockNum:   end,
+		status:        proto.ResyncProgress_QUEUED,
+		source:        source,
+		handler:       &blockDataHandler{ctx: ctx},
+	}
+}
+
+func TestResyncScheduleMerge(t *testing.T) {
+	cases := []struct {
+		name       string
+		start, end uint32
+		source     proto.AddressToResync_Source
+		merged     bool
+		wantStart  uint32
+		wantEnd    uint32
+	}{
+		{"overlapping", 50, 150, proto.AddressToResync_P2P, true, 10, 150},
+		{"adjacent", 101, 200, proto.AddressToResync_P2P, true, 10, 200},
+		{"adjacent before", 1, 9, proto.AddressToResync_P2P, true, 1, 100},
+		{"covered", 20, 30, proto.AddressToResync_P2P, true, 10, 100},
+		{"distant", 1000000, 1000100, proto.AddressToResync_P2P, false, 10, 100},
+		{"other source", 50, 150, proto.AddressToResync_AUTO, false, 10, 100},
+	}
+	for _, c := range cases {
+		scheduler := newResyncScheduler(nil, nil, newResyncJobs())
+		first := scheduler.Schedule(testResyncJob(10, 100, proto.AddressToResync_P2P))
+		job := scheduler.Schedule(testResyncJob(c.start, c.end, c.source))
+		if merged := job == first; merged != c.merged {
+			t.Errorf("%s: merged is %v, want %v", c.name, merged, c.merged)
+		}
+		if len(scheduler.queue) != map[bool]int{true: 1, false: 2}[c.merged] {
+			t.Errorf("%s: %d jobs queued", c.name, len(scheduler.queue))
+		}
+		if first.startBlockNum != c.wantStart || first.endBlockNum != c.wantEnd {
+			t.Errorf("%s: first job range is %d-%d, want %d-%d", c.name,
+				first.startBlockNum, first.endBlockNum, c.wantStart, c.wantEnd)
+		}
+	}
+}
+
+func TestResyncCancelQueuedJob(t *testing.T) {
+	scheduler := newResyncScheduler(nil, nil, newResyncJobs())
+	first := scheduler.Schedule(testResyncJob(10, 100, proto.AddressToResync_P2P))
+	second := scheduler.Schedule(testResyncJob(1000, 1100, proto.AddressToResync_P2P))
+
+	scheduler.Cancel(first)
+	select {
+	case <-first.Done():
+	default:
+		t.Fatal("queued job is not finished on cancel")
+	}
+	if status := first.Progress().Status; status != proto.ResyncProgress_CANCELLED {
+		t.Errorf("cancelled job status is %s", status)
+	}
+	if len(scheduler.queue) != 1 || scheduler.queue[0] != second {
+		t.Errorf("queue is %v, want the second job only", scheduler.queue)
+	}
+	if status := second.Progress().Status; status != proto.ResyncProgress_QUEUED {
+		t.Errorf("second job status is %s", status)
+	}
+}
@@ -0,0 +1,156 @@
+/*
+ * Copyright 2018 Idealnaya rabota LLC
+ * Licensed under Multy.io license.
+ * See LICENSE for details
+ */
+
+package eos
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/Multy-io/Multy-EOS-node-service/proto"
+	"github.com/eoscanada/eos-go"
+)
+
+// testChainStart is a time of block 0, blocks are produced every half a second
+var testChainStart = time.Date(2018, 6, 9, 12, 0, 0, 0, time.UTC)
+
+func testBlockTime(num uint32) time.Time {
+	return testChainStart.Add(time.Duration(num) * 500 * time.Millisecond)
+}
+
+// chainServer serves get_info, get_block and get_account
+// of chain with headBlockNum blocks and accounts created at given times
+func chainServer(t *testing.T, headBlockNum uint32, created map[string]time.Time) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req struct {
+			BlockNumOrID string `json:"block_num_or_id"`
+			AccountName  string `json:"account_name"`
+		}
+		json.NewDecoder(r.Body).Decode(&req)
+		switch r.URL.Path {
+		case "/v1/chain/get_info":
+			json.NewEncoder(w).Encode(map[string]interface{}{"head_block_num": headBlockNum})
+		case "/v1/chain/get_block":
+			num, err := strconv.ParseUint(req.BlockNumOrID, 10, 32)
+			if err != nil || num == 0 || uint32(num) > headBlockNum {
+				http.Error(w, "unknown block", http.StatusInternalServerError)
+				return
+			}
+			json.NewEncoder(w).Encode(map[string]interface{}{
+				"block_num": num,
+				"timestamp": testBlockTime(uint32(num)).Format("2006-01-02T15:04:05.000"),
+			})
+		case "/v1/chain/get_account":
+			createdAt, ok := created[req.AccountName]
+			if !ok {
+				http.Error(w, "unknown account", http.StatusInternalServerError)
+				return
+			}
+			json.NewEncoder(w).Encode(map[string]interface{}{
+				"account_name": req.AccountName,
+				"created":      createdAt.Format("2006-01-02T15:04:05.000"),
+			})
+		default:
+			t.Errorf("unexpected request %s", r.URL.Path)
+			http.NotFound(w, r)
+		}
+	}))
+}
+
+func testChainServer(node *httptest.Server) *Server {
+	return &Server{
+		api:       eos.New(node.URL),
+		rpcAddr:   node.URL,
+		rpcClient: &http.Client{Timeout: rpcRequestTimeout},
+	}
+}
+
+func TestAccountCreationBlock(t *testing.T) {
+	node := chainServer(t, 1000, map[string]time.Time{
+		"first":   testBlockTime(1),
+		"exact":   testBlockTime(377),
+		"between": testBlockTime(500).Add(-200 * time.Millisecond),
+		"head":    testBlockTime(1000),
+		"genesis": testChainStart,
+		"future":  testBlockTime(1001),
+	})
+	defer node.Close()
+	server := testChainServer(node)
+
+	tests := []struct {
+		account string
+		want    uint32
+		fails   bool
+	}{
+		{account: "first", want: 1},
+		{account: "exact", want: 377},
+		{account: "between", want: 500},
+		{account: "head", want: 1000},
+		{account: "genesis", want: 1},
+		{account: "future", fails: true},
+		{account: "unknown", fails: true},
+	}
+	for _, test := range tests {
+		got, err := server.accountCreationBlock(test.account, 1000)
+		if test.fails {
+			if err == nil {
+				t.Errorf("%s: found block %d, want error", test.account, got)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("%s: %s", test.account, err)
+			continue
+		}
+		if got != test.want {
+			t.Errorf("%s: block %d, want %d", test.account, got, test.want)
+		}
+	}
+}
+
+func TestResyncRange(t *testing.T) {
+	node := chainServer(t, 1000, map[string]time.Time{
+		"alice": testBlockTime(200),
+	})
+	defer node.Close()
+	server := testChainServer(node)
+
+	tests := []struct {
+		name       string
+		acc        *proto.AddressToResync
+		start, end uint32
+		fails      bool
+	}{
+		{"whole chain", &proto.AddressToResync{Address: "alice"}, 1, 1000, false},
+		{"range", &proto.AddressToResync{Address: "alice", StartBlock: 10, EndBlock: 20}, 10, 20, false},
+		{"end after head", &proto.AddressToResync{Address: "alice", StartBlock: 10, EndBlock: 2000}, 10, 1000, false},
+		{"from creation", &proto.AddressToResync{Address: "alice", FromCreation: true}, 200, 1000, false},
+		{"from creation overrides start", &proto.AddressToResync{Address: "alice", StartBlock: 10, FromCreation: true}, 200, 1000, false},
+		{"start after end", &proto.AddressToResync{Address: "alice", StartBlock: 30, EndBlock: 20}, 0, 0, true},
+		{"created after end", &proto.AddressToResync{Address: "alice", EndBlock: 100, FromCreation: true}, 0, 0, true},
+		{"unknown account creation", &proto.AddressToResync{Address: "bob", FromCreation: true}, 0, 0, true},
+	}
+	for _, test := range tests {
+		start, end, err := server.resyncRange(test.acc)
+		if test.fails {
+			if err == nil {
+				t.Errorf("%s: range %d-%d, want error", test.name, start, end)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("%s: %s", test.name, err)
+			continue
+		}
+		if start != test.start || end != test.end {
+			t.Errorf("%s: range %d-%d, want %d-%d", test.name, start, end, test.start, test.end)
+		}
+	}
+}
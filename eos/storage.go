@@ -0,0 +1,135 @@
+/*
+ * Copyright 2018 Idealnaya rabota LLC
+ * Licensed under Multy.io license.
+ * See LICENSE for details
+ */
+
+package eos
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/boltdb/bolt"
+)
+
+// UsersStorage is a persistent storage for tracked users.
+// Server loads tracked users from it on start
+// and writes every change through it
+type UsersStorage interface {
+	// Load gets all stored tracked users
+	Load() (map[string][]UserData, error)
+	// Update saves accounts' wallets, overwriting existing accounts,
+	// and deletes accounts at once
+	Update(save map[string][]UserData, remove []string) error
+	// Replace replaces all stored users with given ones
+	Replace(users map[string][]UserData) error
+	// Close releases storage resources
+	Close() error
+}
+
+var usersBucket = []byte("users")
+
+// BoltUsersStorage is a UsersStorage kept in BoltDB file
+type BoltUsersStorage struct {
+	db *bolt.DB
+}
+
+// NewBoltUsersStorage opens (or creates) BoltDB file on path
+func NewBoltUsersStorage(path string) (*BoltUsersStorage, error) {
+	db, err := bolt.Open(path, 0600, &bolt.Options{Timeout: time.Second})
+	if err != nil {
+		return nil, fmt.Errorf("open %s: %s", path, err)
+	}
+	err = db.Update(func(tx *bolt.Tx) error {
+		_, err := tx.CreateBucketIfNotExists(usersBucket)
+		return err
+	})
+	if err != nil {
+		db.Close()
+		return nil, fmt.Errorf("create bucket: %s", err)
+	}
+	return &BoltUsersStorage{
+		db: db,
+	}, nil
+}
+
+// Load gets all stored tracked users.
+// Accounts saved with single wallet (before multiple wallets support)
+// are loaded too
+func (storage *BoltUsersStorage) Load() (map[string][]UserData, error) {
+	users := make(map[string][]UserData)
+	err := storage.db.View(func(tx *bolt.Tx) error {
+		return tx.Bucket(usersBucket).ForEach(func(k, v []byte) error {
+			var accountUsers []UserData
+			var err error
+			if len(v) > 0 && v[0] == '{' {
+				var user UserData
+				err = json.Unmarshal(v, &user)
+				accountUsers = []UserData{user}
+			} else {
+				err = json.Unmarshal(v, &accountUsers)
+			}
+			if err != nil {
+				return fmt.Errorf("user %s: %s", k, err)
+			}
+			users[string(k)] = accountUsers
+			return nil
+		})
+	})
+	return users, err
+}
+
+// Update saves and deletes tracked accounts in a single transaction,
+// so storage is never left with a half of change
+func (storage *BoltUsersStorage) Update(save map[string][]UserData, remove []string) error {
+	return storage.db.Update(func(tx *bolt.Tx) error {
+		bucket := tx.Bucket(usersBucket)
+		err := putUsers(bucket, save)
+		if err != nil {
+			return err
+		}
+		for _, account := range remove {
+			err = bucket.Delete([]byte(account))
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// Replace recreates users bucket with given users in a single transaction
+func (storage *BoltUsersStorage) Replace(users map[string][]UserData) error {
+	return storage.db.Update(func(tx *bolt.Tx) error {
+		err := tx.DeleteBucket(usersBucket)
+		if err != nil {
+			return err
+		}
+		bucket, err := tx.CreateBucket(usersBucket)
+		if err != nil {
+			return err
+		}
+		return putUsers(bucket, users)
+	})
+}
+
+func putUsers(bucket *bolt.Bucket, users map[string][]UserData) error {
+	for account, accountUsers := range users {
+		data, err := json.Marshal(accountUsers)
+		if err != nil {
+			return err
+		}
+		err = bucket.Put([]byte(account), data)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Close closes BoltDB file
+func (storage *BoltUsersStorage) Close() error {
+	return storage.db.Close()
+}
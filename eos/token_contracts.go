@@ -0,0 +1,114 @@
+/*
+ * Copyright 2018 Idealnaya rabota LLC
+ * Licensed under Multy.io license.
+ * See LICENSE for details
+ */
+
+package eos
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/eoscanada/eos-go"
+	"github.com/eoscanada/eos-go/token"
+)
+
+const (
+	// systemTokenContract is a contract of the core token, it's always tracked
+	systemTokenContract = eos.AccountName("eosio.token")
+	// defaultCoreSymbol is a symbol of the mainnet core token
+	defaultCoreSymbol = "EOS"
+)
+
+// tokenContracts decides which contracts' transfers are decoded
+// with eosio.token transfer struct. eosio.token is always tracked,
+// other contracts are tracked unless they are denied
+type tokenContracts struct {
+	// allow is a list of tracked contracts, empty to track all
+	allow map[eos.AccountName]struct{}
+	deny  map[eos.AccountName]struct{}
+	// coreSymbol is a symbol of the core token, transfers of it are
+	// counterfeit unless they are made by systemTokenContract
+	coreSymbol string
+}
+
+func newTokenContracts() *tokenContracts {
+	return &tokenContracts{
+		allow:      make(map[eos.AccountName]struct{}),
+		deny:       make(map[eos.AccountName]struct{}),
+		coreSymbol: defaultCoreSymbol,
+	}
+}
+
+// Set replaces allow and deny lists, it must not be called
+// while blocks are handled
+func (tokens *tokenContracts) Set(allow, deny []string) {
+	tokens.allow = make(map[eos.AccountName]struct{}, len(allow))
+	for _, contract := range allow {
+		tokens.allow[eos.AccountName(contract)] = struct{}{}
+	}
+	tokens.deny = make(map[eos.AccountName]struct{}, len(deny))
+	for _, contract := range deny {
+		tokens.deny[eos.AccountName(contract)] = struct{}{}
+	}
+}
+
+// SetCoreSymbol sets symbol of the core token, empty for EOS.
+// It must not be called while blocks are handled
+func (tokens *tokenContracts) SetCoreSymbol(symbol string) {
+	if symbol == "" {
+		symbol = defaultCoreSymbol
+	}
+	tokens.coreSymbol = symbol
+}
+
+// Tracked checks if contract transfers are decoded
+func (tokens *tokenContracts) Tracked(contract eos.AccountName) bool {
+	if contract == systemTokenContract || tokens == nil {
+		return true
+	}
+	if _, ok := tokens.deny[contract]; ok {
+		return false
+	}
+	if len(tokens.allow) == 0 {
+		return true
+	}
+	_, ok := tokens.allow[contract]
+	return ok
+}
+
+// Counterfeit checks if contract's transfer of symbol fakes the core token
+func (tokens *tokenContracts) Counterfeit(contract eos.AccountName, symbol string) bool {
+	coreSymbol := defaultCoreSymbol
+	if tokens != nil {
+		coreSymbol = tokens.coreSymbol
+	}
+	return contract != systemTokenContract && symbol == coreSymbol
+}
+
+// decodeTransfer decodes transfer of contract unknown to eos-go.
+// Action got from p2p has binary data only,
+// action got from history api has json data too
+func decodeTransfer(action *eos.Action) (*token.Transfer, error) {
+	transfer := &token.Transfer{}
+	if len(action.HexData) != 0 {
+		err := eos.UnmarshalBinary(action.HexData, transfer)
+		if err != nil {
+			return nil, fmt.Errorf("unmarshal %s transfer: %s", action.Account, err)
+		}
+		return transfer, nil
+	}
+	if data, ok := action.Data.(map[string]interface{}); ok {
+		dataJSON, err := json.Marshal(data)
+		if err != nil {
+			return nil, err
+		}
+		err = json.Unmarshal(dataJSON, transfer)
+		if err != nil {
+			return nil, fmt.Errorf("unmarshal %s transfer: %s", action.Account, err)
+		}
+		return transfer, nil
+	}
+	return nil, fmt.Errorf("%s transfer has no data", action.Account)
+}
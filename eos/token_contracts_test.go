@@ -0,0 +1,74 @@
+/*
+ * Copyright 2018 Idealnaya rabota LLC
+ * Licensed under Multy.io license.
+ * See LICENSE for details
+ */
+
+package eos
+
+import (
+	"testing"
+
+	"github.com/eoscanada/eos-go"
+)
+
+func TestTokenContractsTracked(t *testing.T) {
+	tests := []struct {
+		name     string
+		allow    []string
+		deny     []string
+		contract eos.AccountName
+		tracked  bool
+	}{
+		{"system by default", nil, nil, systemTokenContract, true},
+		{"any by default", nil, nil, "sometoken", true},
+		{"denied", nil, []string{"sometoken"}, "sometoken", false},
+		{"not denied", nil, []string{"sometoken"}, "othertoken", true},
+		{"allowed", []string{"sometoken"}, nil, "sometoken", true},
+		{"not allowed", []string{"sometoken"}, nil, "othertoken", false},
+		{"allowed and denied", []string{"sometoken"}, []string{"sometoken"}, "sometoken", false},
+		{"system not allowed", []string{"sometoken"}, nil, systemTokenContract, true},
+		{"system denied", nil, []string{string(systemTokenContract)}, systemTokenContract, true},
+	}
+	for _, test := range tests {
+		tokens := newTokenContracts()
+		tokens.Set(test.allow, test.deny)
+		if got := tokens.Tracked(test.contract); got != test.tracked {
+			t.Errorf("%s: tracked %v, want %v", test.name, got, test.tracked)
+		}
+	}
+
+	var tokens *tokenContracts
+	if !tokens.Tracked("sometoken") {
+		t.Error("nil contracts don't track sometoken")
+	}
+}
+
+func TestTokenContractsCounterfeit(t *testing.T) {
+	tests := []struct {
+		name        string
+		coreSymbol  string
+		contract    eos.AccountName
+		symbol      string
+		counterfeit bool
+	}{
+		{"system core", "", systemTokenContract, "EOS", false},
+		{"other core", "", "sometoken", "EOS", true},
+		{"other token", "", "sometoken", "SYS", false},
+		{"custom core", "SYS", "sometoken", "SYS", true},
+		{"custom system core", "SYS", systemTokenContract, "SYS", false},
+		{"mainnet symbol on custom chain", "SYS", "sometoken", "EOS", false},
+	}
+	for _, test := range tests {
+		tokens := newTokenContracts()
+		tokens.SetCoreSymbol(test.coreSymbol)
+		if got := tokens.Counterfeit(test.contract, test.symbol); got != test.counterfeit {
+			t.Errorf("%s: counterfeit %v, want %v", test.name, got, test.counterfeit)
+		}
+	}
+
+	var tokens *tokenContracts
+	if !tokens.Counterfeit("sometoken", defaultCoreSymbol) {
+		t.Error("nil contracts don't guard core symbol")
+	}
+}
@@ -0,0 +1,265 @@
+/*
+ * Copyright 2018 Idealnaya rabota LLC
+ * Licensed under Multy.io license.
+ * See LICENSE for details
+ */
+
+package eos
+
+import (
+	"bytes"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/Multy-io/Multy-EOS-node-service/proto"
+	"github.com/eoscanada/eos-go"
+)
+
+// traceSource gets executed actions of transaction
+// including inline actions and notifications
+type traceSource interface {
+	// TransactionTraces gets traces of transaction's actions in execution order
+	TransactionTraces(blockNum uint32, transactionID eos.SHA256Bytes) ([]actionTrace, error)
+}
+
+// actionTrace is an action execution by receiver.
+// Inline traces are notifications of the action receivers
+// followed by inline actions it sent
+type actionTrace struct {
+	Receipt struct {
+		Receiver       eos.AccountName `json:"receiver"`
+		GlobalSequence traceSeq        `json:"global_sequence"`
+	} `json:"receipt"`
+	Act          eos.Action    `json:"act"`
+	InlineTraces []actionTrace `json:"inline_traces"`
+}
+
+// traceSeq is an action global sequence,
+// node sends big numbers as strings
+type traceSeq uint64
+
+func (seq *traceSeq) UnmarshalJSON(data []byte) error {
+	v, err := strconv.ParseUint(strings.Trim(string(data), `"`), 10, 64)
+	if err != nil {
+		return fmt.Errorf("global_sequence: %s", err)
+	}
+	*seq = traceSeq(v)
+	return nil
+}
+
+const (
+	// traceFetchWorkers is a number of concurrent trace requests of a block
+	traceFetchWorkers = 8
+	// traceRequestTimeout limits trace request, so slow node
+	// doesn't stall block processing
+	traceRequestTimeout = 5 * time.Second
+)
+
+// historyTraces gets traces from history_api_plugin get_transaction,
+// it's one request per transaction, so it's a stand-in
+// for a node streaming traces
+type historyTraces struct {
+	rpcAddr string
+	client  *http.Client
+}
+
+func newHistoryTraces(rpcAddr string) *historyTraces {
+	return &historyTraces{
+		rpcAddr: rpcAddr,
+		client:  &http.Client{Timeout: traceRequestTimeout},
+	}
+}
+
+// TransactionTraces gets transaction's traces. get_transaction returns
+// every stored trace with nested inline traces, so top level ones
+// are the traces not nested into others
+func (api *historyTraces) TransactionTraces(blockNum uint32, transactionID eos.SHA256Bytes) ([]actionTrace, error) {
+	reqJSON, err := json.Marshal(map[string]interface{}{
+		"id":             hex.EncodeToString(transactionID),
+		"block_num_hint": blockNum,
+	})
+	if err != nil {
+		return nil, err
+	}
+	resp, err := api.client.Post(fmt.Sprintf("%s/v1/history/get_transaction", api.rpcAddr),
+		"application/json", bytes.NewReader(reqJSON))
+	if err != nil {
+		return nil, fmt.Errorf("get_transaction: %s", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode == http.StatusNotFound {
+		return nil, errHistoryUnavailable
+	}
+	if resp.StatusCode != http.StatusOK {
+		bs, _ := ioutil.ReadAll(resp.Body)
+		return nil, fmt.Errorf("get_transaction: response not ok: %v", string(bs))
+	}
+
+	var trx struct {
+		Traces []actionTrace `json:"traces"`
+	}
+	err = json.NewDecoder(resp.Body).Decode(&trx)
+	if err != nil {
+		return nil, fmt.Errorf("get_transaction: %s", err)
+	}
+	if len(trx.Traces) == 0 {
+		return nil, fmt.Errorf("get_transaction: no traces of %x", transactionID)
+	}
+
+	nested := make(map[traceSeq]struct{})
+	var collect func(traces []actionTrace)
+	collect = func(traces []actionTrace) {
+		for i := range traces {
+			nested[traces[i].Receipt.GlobalSequence] = struct{}{}
+			collect(traces[i].InlineTraces)
+		}
+	}
+	for i := range trx.Traces {
+		collect(trx.Traces[i].InlineTraces)
+	}
+	var traces []actionTrace
+	for _, trace := range trx.Traces {
+		if _, ok := nested[trace.Receipt.GlobalSequence]; !ok {
+			traces = append(traces, trace)
+		}
+	}
+	sort.Slice(traces, func(i, j int) bool {
+		return traces[i].Receipt.GlobalSequence < traces[j].Receipt.GlobalSequence
+	})
+	return traces, nil
+}
+
+// fetchTraces gets traces of block's executed transactions concurrently
+// by transaction number. Transactions which traces aren't got are missing
+func (handler *blockDataHandler) fetchTraces(block *eos.SignedBlock) map[int][]actionTrace {
+	if handler.traces == nil {
+		return nil
+	}
+	var txNums []int
+	for txNum := range block.Transactions {
+		if block.Transactions[txNum].Status == eos.TransactionStatusExecuted {
+			txNums = append(txNums, txNum)
+		}
+	}
+	if len(txNums) == 0 {
+		return nil
+	}
+
+	blockNum := block.BlockNumber()
+	traces := make(map[int][]actionTrace, len(txNums))
+	var mu sync.Mutex
+	txNumCh := make(chan int)
+	workers := traceFetchWorkers
+	if len(txNums) < workers {
+		workers = len(txNums)
+	}
+	var wg sync.WaitGroup
+	wg.Add(workers)
+	for i := 0; i < workers; i++ {
+		go func() {
+			defer wg.Done()
+			for txNum := range txNumCh {
+				txTraces, err := handler.traces.TransactionTraces(blockNum, block.Transactions[txNum].Transaction.ID)
+				if err != nil {
+					log.Debugf("traces: %s (block %d, %s)", err, blockNum, handler.name)
+					continue
+				}
+				mu.Lock()
+				traces[txNum] = txTraces
+				mu.Unlock()
+			}
+		}()
+	}
+	for _, txNum := range txNums {
+		txNumCh <- txNum
+	}
+	close(txNumCh)
+	wg.Wait()
+	return traces
+}
+
+// traceWalker numbers transaction's executed actions
+// in execution order and sends them
+type traceWalker struct {
+	handler *blockDataHandler
+	users   usersSnapshot
+	txIndex uint32
+	// receipt is a template of every transaction's action
+	receipt proto.Action
+	// contextFree is a number of context free actions,
+	// they are executed before others
+	contextFree int
+	// next is an index of the next executed action
+	next uint32
+}
+
+// processTraces sends transaction's executed actions
+// with inline actions and notifications
+func (handler *blockDataHandler) processTraces(users usersSnapshot, traces []actionTrace, contextFree int, txIndex uint32, receipt proto.Action) {
+	walker := &traceWalker{
+		handler:     handler,
+		users:       users,
+		txIndex:     txIndex,
+		receipt:     receipt,
+		contextFree: contextFree,
+	}
+	for i := range traces {
+		walker.walk(&traces[i], 0, 0)
+	}
+}
+
+// walk sends action to involved accounts. Notified tracked accounts
+// get it with their notification, so it's sent once to every account
+func (walker *traceWalker) walk(trace *actionTrace, depth uint32, parent int64) {
+	index := walker.next
+	walker.next++
+
+	var notifications, inlines []*actionTrace
+	var notified []string
+	for i := range trace.InlineTraces {
+		child := &trace.InlineTraces[i]
+		if child.Receipt.Receiver == child.Act.Account {
+			inlines = append(inlines, child)
+			continue
+		}
+		// newer nodes nest inline actions sent by receiver into its notification
+		for j := range child.InlineTraces {
+			inlines = append(inlines, &child.InlineTraces[j])
+		}
+		if _, ok := walker.users.Get(string(child.Receipt.Receiver)); ok {
+			notifications = append(notifications, child)
+			notified = append(notified, string(child.Receipt.Receiver))
+		}
+	}
+
+	toSend := walker.receipt
+	toSend.ActionIndex = int64(index)
+	toSend.InlineDepth = depth
+	toSend.ContextFree = depth == 0 && int(index) < walker.contextFree
+	if depth != 0 {
+		toSend.ParentActionIndex = parent
+	}
+	pos := cursor{
+		blockNum:    toSend.BlockNum,
+		txIndex:     walker.txIndex,
+		actionIndex: index,
+	}
+	walker.handler.mapAction(walker.users.without(notified), &trace.Act, toSend, &pos)
+	for _, notification := range notifications {
+		toSend.Receiver = string(notification.Receipt.Receiver)
+		walker.handler.mapAction(walker.users.only(toSend.Receiver), &notification.Act, toSend, &pos)
+	}
+	walker.handler.flush()
+
+	for _, inline := range inlines {
+		walker.walk(inline, depth+1, int64(index))
+	}
+}
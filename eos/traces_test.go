@@ -0,0 +1,100 @@
+/*
+ * Copyright 2018 Idealnaya rabota LLC
+ * Licensed under Multy.io license.
+ * See LICENSE for details
+ */
+
+package eos
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+
+	"github.com/Multy-io/Multy-EOS-node-service/proto"
+	"github.com/eoscanada/eos-go"
+	"github.com/eoscanada/eos-go/token"
+)
+
+// fakeTraces gets traces of transactions by first id byte
+type fakeTraces struct {
+	mu       sync.Mutex
+	requests int
+	traces   map[byte][]actionTrace
+}
+
+func (source *fakeTraces) TransactionTraces(blockNum uint32, transactionID eos.SHA256Bytes) ([]actionTrace, error) {
+	source.mu.Lock()
+	defer source.mu.Unlock()
+	source.requests++
+	traces, ok := source.traces[transactionID[0]]
+	if !ok {
+		return nil, errors.New("no traces")
+	}
+	return traces, nil
+}
+
+func TestFetchTraces(t *testing.T) {
+	source := &fakeTraces{
+		traces: map[byte][]actionTrace{1: {{}}, 3: {{}, {}}},
+	}
+	handler := &blockDataHandler{traces: source}
+	block := testBlock(5)
+	for i, status := range []eos.TransactionStatus{
+		eos.TransactionStatusExecuted,
+		eos.TransactionStatusExecuted,
+		eos.TransactionStatusHardFail,
+		eos.TransactionStatusExecuted,
+	} {
+		var receipt eos.TransactionReceipt
+		receipt.Status = status
+		receipt.Transaction.ID = eos.SHA256Bytes{byte(i)}
+		block.Transactions = append(block.Transactions, receipt)
+	}
+
+	traces := handler.fetchTraces(block)
+	if source.requests != 3 {
+		t.Errorf("%d traces requested, want 3 of executed transactions", source.requests)
+	}
+	if len(traces) != 2 || len(traces[1]) != 1 || len(traces[3]) != 2 {
+		t.Errorf("got traces of %d transactions, want 1 and 3: %v", len(traces), traces)
+	}
+}
+
+func TestProcessTracesSendsOncePerAccount(t *testing.T) {
+	transfer := eos.Action{
+		Account: "eosio.token",
+		Name:    "transfer",
+		ActionData: eos.ActionData{
+			Data: &token.Transfer{From: "alice", To: "bob"},
+		},
+	}
+	trace := actionTrace{Act: transfer, InlineTraces: []actionTrace{{Act: transfer}, {Act: transfer}}}
+	trace.Receipt.Receiver = "eosio.token"
+	trace.InlineTraces[0].Receipt.Receiver = "alice"
+	trace.InlineTraces[1].Receipt.Receiver = "bob"
+
+	history := make(chan proto.Action, 16)
+	handler := &blockDataHandler{
+		ctx:     context.Background(),
+		history: history,
+	}
+	users := usersSnapshot{
+		"alice": {{UserID: "a"}},
+		"bob":   {{UserID: "b"}},
+	}
+	handler.processTraces(users, []actionTrace{trace}, 0, 0, proto.Action{BlockNum: 5})
+	close(history)
+
+	sent := make(map[string]string)
+	for action := range history {
+		if receiver, ok := sent[action.Address]; ok {
+			t.Errorf("%s got action twice, with receivers %q and %q", action.Address, receiver, action.Receiver)
+		}
+		sent[action.Address] = action.Receiver
+	}
+	if sent["alice"] != "alice" || sent["bob"] != "bob" {
+		t.Errorf("accounts must get their notifications, got %v", sent)
+	}
+}
@@ -0,0 +1,279 @@
+/*
+ * Copyright 2018 Idealnaya rabota LLC
+ * Licensed under Multy.io license.
+ * See LICENSE for details
+ */
+
+package eos
+
+import (
+	"sync"
+	"sync/atomic"
+)
+
+// usersSnapshot is an immutable view of tracked users.
+// One account may be tracked by several multy wallets.
+// It is shared between goroutines, so never modify it
+type usersSnapshot map[string][]UserData
+
+// Get gets wallets tracking account
+func (snapshot usersSnapshot) Get(account string) ([]UserData, bool) {
+	users, ok := snapshot[account]
+	return users, ok
+}
+
+// only gets view of snapshot with account users only
+func (snapshot usersSnapshot) only(account string) usersView {
+	return usersOnly{snapshot: snapshot, account: account}
+}
+
+// without gets view of snapshot without accounts, snapshot isn't copied
+func (snapshot usersSnapshot) without(accounts []string) usersView {
+	if len(accounts) == 0 {
+		return snapshot
+	}
+	return usersWithout{snapshot: snapshot, accounts: accounts}
+}
+
+// usersView gets wallets tracking account
+type usersView interface {
+	Get(account string) ([]UserData, bool)
+}
+
+// usersOnly is a view of snapshot with single account
+type usersOnly struct {
+	snapshot usersSnapshot
+	account  string
+}
+
+func (view usersOnly) Get(account string) ([]UserData, bool) {
+	if account != view.account {
+		return nil, false
+	}
+	return view.snapshot.Get(account)
+}
+
+// usersWithout is a view of snapshot without few accounts
+type usersWithout struct {
+	snapshot usersSnapshot
+	accounts []string
+}
+
+func (view usersWithout) Get(account string) ([]UserData, bool) {
+	for _, excluded := range view.accounts {
+		if account == excluded {
+			return nil, false
+		}
+	}
+	return view.snapshot.Get(account)
+}
+
+// trackedUsers is a concurrency-safe index of tracked accounts.
+// Readers take copy-on-write snapshots without locking,
+// writers copy current snapshot, change it and swap.
+// If storage is set, every change is written through it
+type trackedUsers struct {
+	// mu serializes writers
+	mu       sync.Mutex
+	snapshot atomic.Value // usersSnapshot
+
+	storage UsersStorage
+}
+
+// newTrackedUsers makes in-memory index of users
+func newTrackedUsers(users map[string][]UserData) *trackedUsers {
+	snapshot := make(usersSnapshot, len(users))
+	for account, accountUsers := range users {
+		snapshot[account] = appendUsers(nil, accountUsers...)
+	}
+	tracked := &trackedUsers{}
+	tracked.snapshot.Store(snapshot)
+	return tracked
+}
+
+// loadTrackedUsers makes index of users stored in storage
+func loadTrackedUsers(storage UsersStorage) (*trackedUsers, error) {
+	users, err := storage.Load()
+	if err != nil {
+		return nil, err
+	}
+	tracked := newTrackedUsers(users)
+	tracked.storage = storage
+	return tracked, nil
+}
+
+// Snapshot gets current immutable view of tracked users
+func (tracked *trackedUsers) Snapshot() usersSnapshot {
+	return tracked.snapshot.Load().(usersSnapshot)
+}
+
+// Get gets wallets tracking account
+func (tracked *trackedUsers) Get(account string) ([]UserData, bool) {
+	return tracked.Snapshot().Get(account)
+}
+
+// Len gets number of tracked accounts
+func (tracked *trackedUsers) Len() int {
+	return len(tracked.Snapshot())
+}
+
+// Add adds wallets to accounts, already tracking wallets are skipped.
+// Index is not changed if storage fails
+func (tracked *trackedUsers) Add(users map[string][]UserData) error {
+	return tracked.change(func(next usersSnapshot) []string {
+		var changed []string
+		for account, accountUsers := range users {
+			merged := appendUsers(next[account], accountUsers...)
+			if len(merged) != len(next[account]) {
+				next[account] = merged
+				changed = append(changed, account)
+			}
+		}
+		return changed
+	})
+}
+
+// RemoveAccount stops tracking of account by all the wallets
+func (tracked *trackedUsers) RemoveAccount(account string) error {
+	return tracked.change(func(next usersSnapshot) []string {
+		if _, ok := next[account]; !ok {
+			return nil
+		}
+		delete(next, account)
+		return []string{account}
+	})
+}
+
+// RemoveWallet stops tracking of account by single wallet
+func (tracked *trackedUsers) RemoveWallet(account string, user UserData) error {
+	return tracked.change(func(next usersSnapshot) []string {
+		left := filterUsers(next[account], func(u UserData) bool { return u != user })
+		if len(left) == len(next[account]) {
+			return nil
+		}
+		setUsers(next, account, left)
+		return []string{account}
+	})
+}
+
+// RemoveUser stops tracking of all the accounts by user's wallets
+// and returns accounts that user tracked
+func (tracked *trackedUsers) RemoveUser(userID string) ([]string, error) {
+	var accounts []string
+	err := tracked.change(func(next usersSnapshot) []string {
+		for account, accountUsers := range next {
+			left := filterUsers(accountUsers, func(u UserData) bool { return u.UserID != userID })
+			if len(left) != len(accountUsers) {
+				setUsers(next, account, left)
+				accounts = append(accounts, account)
+			}
+		}
+		return accounts
+	})
+	return accounts, err
+}
+
+// Replace replaces all the tracked users at once
+func (tracked *trackedUsers) Replace(users map[string][]UserData) error {
+	tracked.mu.Lock()
+	defer tracked.mu.Unlock()
+
+	next := make(usersSnapshot, len(users))
+	for account, accountUsers := range users {
+		setUsers(next, account, appendUsers(nil, accountUsers...))
+	}
+
+	if tracked.storage != nil {
+		err := tracked.storage.Replace(next)
+		if err != nil {
+			return err
+		}
+	}
+	tracked.snapshot.Store(next)
+	return nil
+}
+
+// change applies fn to a copy of current snapshot
+// and writes accounts changed by fn to storage.
+// Accounts left without wallets are deleted
+func (tracked *trackedUsers) change(fn func(next usersSnapshot) []string) error {
+	tracked.mu.Lock()
+	defer tracked.mu.Unlock()
+
+	next := tracked.copySnapshot()
+	changed := fn(next)
+	if len(changed) == 0 {
+		return nil
+	}
+
+	if tracked.storage != nil {
+		save := make(map[string][]UserData)
+		var remove []string
+		for _, account := range changed {
+			if users, ok := next[account]; ok {
+				save[account] = users
+			} else {
+				remove = append(remove, account)
+			}
+		}
+		err := tracked.storage.Update(save, remove)
+		if err != nil {
+			return err
+		}
+	}
+	tracked.snapshot.Store(next)
+	return nil
+}
+
+// copySnapshot makes copy of current snapshot.
+// Users slices are shared, so replace them instead of modifying
+func (tracked *trackedUsers) copySnapshot() usersSnapshot {
+	current := tracked.Snapshot()
+	next := make(usersSnapshot, len(current))
+	for account, users := range current {
+		next[account] = users
+	}
+	return next
+}
+
+// appendUsers makes new slice with users added to dst
+// skipping duplicates
+func appendUsers(dst []UserData, users ...UserData) []UserData {
+	result := make([]UserData, len(dst), len(dst)+len(users))
+	copy(result, dst)
+	for _, user := range users {
+		if !containsUser(result, user) {
+			result = append(result, user)
+		}
+	}
+	return result
+}
+
+func containsUser(users []UserData, user UserData) bool {
+	for _, u := range users {
+		if u == user {
+			return true
+		}
+	}
+	return false
+}
+
+// filterUsers makes new slice of users that keep returns true for
+func filterUsers(users []UserData, keep func(UserData) bool) []UserData {
+	var result []UserData
+	for _, user := range users {
+		if keep(user) {
+			result = append(result, user)
+		}
+	}
+	return result
+}
+
+// setUsers sets account users, deleting account with no users
+func setUsers(snapshot usersSnapshot, account string, users []UserData) {
+	if len(users) == 0 {
+		delete(snapshot, account)
+		return
+	}
+	snapshot[account] = users
+}
@@ -0,0 +1,185 @@
+/*
+ * Copyright 2018 Idealnaya rabota LLC
+ * Licensed under Multy.io license.
+ * See LICENSE for details
+ */
+
+package eos
+
+import (
+	"context"
+	"errors"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+
+	"github.com/Multy-io/Multy-EOS-node-service/proto"
+	"github.com/eoscanada/eos-go"
+	"github.com/eoscanada/eos-go/token"
+)
+
+// TestTrackedUsersConcurrentChanges changes tracked users while handler
+// processes blocks and actions, it's meant to be run with -race
+func TestTrackedUsersConcurrentChanges(t *testing.T) {
+	dir, err := ioutil.TempDir("", "tracked-users")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	storage, err := NewBoltUsersStorage(filepath.Join(dir, "users.db"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer storage.Close()
+
+	alice := UserData{UserID: "alice-user", WalletIndex: 1}
+	bob := UserData{UserID: "bob-user", WalletIndex: 2}
+	tracked, err := loadTrackedUsers(storage)
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = tracked.Add(map[string][]UserData{"alice": {alice}})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	history := make(chan proto.Action, 16)
+	handler := &blockDataHandler{
+		ctx:          ctx,
+		history:      history,
+		trackedUsers: tracked,
+	}
+	transfer := &eos.Action{
+		Account: "eosio.token",
+		Name:    "transfer",
+		ActionData: eos.ActionData{
+			Data: &token.Transfer{
+				From:     "alice",
+				To:       "bob",
+				Quantity: eos.Asset{Amount: 10000, Symbol: eos.Symbol{Precision: 4, Symbol: "EOS"}},
+			},
+		},
+	}
+
+	received := make(chan []proto.Action)
+	go func() {
+		var actions []proto.Action
+		for action := range history {
+			actions = append(actions, action)
+		}
+		received <- actions
+	}()
+
+	const iterations = 200
+	var wg sync.WaitGroup
+	wg.Add(4)
+	go func() {
+		defer wg.Done()
+		for i := 0; i < iterations; i++ {
+			if err := tracked.Add(map[string][]UserData{"bob": {bob}, "alice": {alice}}); err != nil {
+				t.Error(err)
+				return
+			}
+		}
+	}()
+	go func() {
+		defer wg.Done()
+		for i := 0; i < iterations; i++ {
+			if err := tracked.RemoveWallet("bob", bob); err != nil {
+				t.Error(err)
+				return
+			}
+		}
+	}()
+	go func() {
+		defer wg.Done()
+		for i := 0; i < iterations; i++ {
+			if err := tracked.Replace(map[string][]UserData{"alice": {alice}}); err != nil {
+				t.Error(err)
+				return
+			}
+		}
+	}()
+	go func() {
+		defer wg.Done()
+		for i := uint32(1); i <= iterations; i++ {
+			handler.HandleBlock(testBlock(i))
+			handler.processAction(handler.trackedUsers.Snapshot(), transfer, cursor{blockNum: i}, nil)
+		}
+	}()
+	wg.Wait()
+	close(history)
+
+	actions := <-received
+	if len(actions) < iterations {
+		t.Errorf("%d actions sent, want at least %d for alice", len(actions), iterations)
+	}
+	for _, action := range actions {
+		switch action.Address {
+		case "alice":
+			if action.UserID != alice.UserID {
+				t.Errorf("alice's action is sent to %s", action.UserID)
+			}
+		case "bob":
+			if action.UserID != bob.UserID {
+				t.Errorf("bob's action is sent to %s", action.UserID)
+			}
+		default:
+			t.Errorf("action is sent to untracked %s", action.Address)
+		}
+	}
+
+	// index and storage must agree after all the changes
+	stored, err := storage.Load()
+	if err != nil {
+		t.Fatal(err)
+	}
+	snapshot := tracked.Snapshot()
+	if len(stored) != len(snapshot) {
+		t.Fatalf("%d accounts stored, %d tracked", len(stored), len(snapshot))
+	}
+	for account, users := range snapshot {
+		if len(stored[account]) != len(users) {
+			t.Errorf("%s: %d wallets stored, %d tracked", account, len(stored[account]), len(users))
+		}
+	}
+}
+
+// failingStorage fails every update
+type failingStorage struct {
+	UsersStorage
+	updates int
+}
+
+func (storage *failingStorage) Update(save map[string][]UserData, remove []string) error {
+	storage.updates++
+	return errors.New("disk is full")
+}
+
+func TestTrackedUsersChangeIsWrittenAtOnce(t *testing.T) {
+	storage := &failingStorage{}
+	tracked := newTrackedUsers(map[string][]UserData{
+		"alice": {{UserID: "a"}},
+		"bob":   {{UserID: "a"}, {UserID: "b"}},
+	})
+	tracked.storage = storage
+
+	// alice is deleted and bob is saved by the same change
+	_, err := tracked.RemoveUser("a")
+	if err == nil {
+		t.Fatal("storage error is lost")
+	}
+	if storage.updates != 1 {
+		t.Errorf("change is written in %d updates, want 1", storage.updates)
+	}
+	if users, _ := tracked.Get("alice"); len(users) != 1 {
+		t.Error("alice is removed from index while storage failed")
+	}
+	if users, _ := tracked.Get("bob"); len(users) != 2 {
+		t.Error("bob is changed in index while storage failed")
+	}
+}
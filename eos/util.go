@@ -20,3 +20,78 @@ func asset(a eos.Asset) *proto.Asset {
 		Symbol:    a.Symbol.Symbol,
 	}
 }
+
+// stakeSum sums cpu and net stakes, nil if their symbols differ
+// as eos-go panics on such sum and delayed transactions aren't validated
+func stakeSum(cpu, net eos.Asset) *proto.Asset {
+	if cpu.Symbol != net.Symbol {
+		return nil
+	}
+	return asset(cpu.Add(net))
+}
+
+// permission constructs protobuf permission struct
+// from eos-go authority struct
+func permission(name, parent eos.PermissionName, auth eos.Authority) *proto.Permission {
+	perm := &proto.Permission{
+		Name:      string(name),
+		Parent:    string(parent),
+		Threshold: auth.Threshold,
+	}
+	for _, key := range auth.Keys {
+		perm.Keys = append(perm.Keys, &proto.KeyWeight{
+			Key:    key.PublicKey.String(),
+			Weight: uint32(key.Weight),
+		})
+	}
+	for _, acc := range auth.Accounts {
+		perm.Accounts = append(perm.Accounts, &proto.PermissionLevelWeight{
+			Actor:      string(acc.Permission.Actor),
+			Permission: string(acc.Permission.Permission),
+			Weight:     uint32(acc.Weight),
+		})
+	}
+	for _, wait := range auth.Waits {
+		perm.Waits = append(perm.Waits, &proto.WaitWeight{
+			WaitSec: wait.WaitSec,
+			Weight:  uint32(wait.Weight),
+		})
+	}
+	return perm
+}
+
+// permissionLevel constructs protobuf permission level struct
+// from eos-go permission level struct
+func permissionLevel(level eos.PermissionLevel) *proto.PermissionLevel {
+	return &proto.PermissionLevel{
+		Actor:      string(level.Actor),
+		Permission: string(level.Permission),
+	}
+}
+
+// usersData converts protobuf users map and addresses list
+// to tracked users
+func usersData(userData *proto.UsersData) map[string][]UserData {
+	users := make(map[string][]UserData, len(userData.GetMap()))
+	for key, val := range userData.GetMap() {
+		// TODO: check if account exist?
+		users[key] = append(users[key], UserData{
+			AddressIndex: val.AddressIndex,
+			UserID:       val.UserID,
+			WalletIndex:  val.WalletIndex,
+		})
+	}
+	for _, acc := range userData.GetAddresses() {
+		users[acc.Address] = append(users[acc.Address], watchAddressUser(acc))
+	}
+	return users
+}
+
+// watchAddressUser gets user data of watch address
+func watchAddressUser(acc *proto.WatchAddress) UserData {
+	return UserData{
+		WalletIndex:  acc.WalletIndex,
+		UserID:       acc.UserID,
+		AddressIndex: acc.AddressIndex,
+	}
+}
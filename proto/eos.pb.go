@@ -22,12 +22,29 @@ It has these top-level messages:
 	AddressExtended
 	ReplyInfo
 	WatchAddress
+	UserID
+	NewTxReq
+	NewTxStream
+	NewTxStreamsList
 	BlockHeight
 	AddressToResync
+	ResyncJobID
+	ResyncProgress
+	ResyncJobsList
+	AccountHistoryReq
+	AccountHistory
 	Balance
 	RawTx
 	SendTxResp
 	Action
+	Proposal
+	ProposedAction
+	PermissionLevel
+	Permission
+	KeyWeight
+	PermissionLevelWeight
+	WaitWeight
+	PermissionLink
 	BalanceReq
 	Account
 	Asset
@@ -61,6 +78,60 @@ var _ = math.Inf
 // proto package needs to be updated.
 const _ = proto1.ProtoPackageIsVersion2 // please upgrade the proto package
 
+type AddressToResync_Source int32
+
+const (
+	AddressToResync_AUTO        AddressToResync_Source = 0
+	AddressToResync_P2P         AddressToResync_Source = 1
+	AddressToResync_HISTORY_API AddressToResync_Source = 2
+)
+
+var AddressToResync_Source_name = map[int32]string{
+	0: "AUTO",
+	1: "P2P",
+	2: "HISTORY_API",
+}
+var AddressToResync_Source_value = map[string]int32{
+	"AUTO":        0,
+	"P2P":         1,
+	"HISTORY_API": 2,
+}
+
+func (x AddressToResync_Source) String() string {
+	return proto1.EnumName(AddressToResync_Source_name, int32(x))
+}
+func (AddressToResync_Source) EnumDescriptor() ([]byte, []int) { return fileDescriptor0, []int{11, 0} }
+
+type ResyncProgress_Status int32
+
+const (
+	ResyncProgress_RUNNING   ResyncProgress_Status = 0
+	ResyncProgress_DONE      ResyncProgress_Status = 1
+	ResyncProgress_FAILED    ResyncProgress_Status = 2
+	ResyncProgress_CANCELLED ResyncProgress_Status = 3
+	ResyncProgress_QUEUED    ResyncProgress_Status = 4
+)
+
+var ResyncProgress_Status_name = map[int32]string{
+	0: "RUNNING",
+	1: "DONE",
+	2: "FAILED",
+	3: "CANCELLED",
+	4: "QUEUED",
+}
+var ResyncProgress_Status_value = map[string]int32{
+	"RUNNING":   0,
+	"DONE":      1,
+	"FAILED":    2,
+	"CANCELLED": 3,
+	"QUEUED":    4,
+}
+
+func (x ResyncProgress_Status) String() string {
+	return proto1.EnumName(ResyncProgress_Status_name, int32(x))
+}
+func (ResyncProgress_Status) EnumDescriptor() ([]byte, []int) { return fileDescriptor0, []int{13, 0} }
+
 type Action_Type int32
 
 const (
@@ -69,14 +140,48 @@ const (
 	Action_BUY_RAM_BYTES  Action_Type = 2
 	Action_BUY_RAM        Action_Type = 3
 	Action_SELL_RAM       Action_Type = 4
+	Action_DELEGATE_BW    Action_Type = 5
+	Action_UNDELEGATE_BW  Action_Type = 6
+	Action_REFUND         Action_Type = 7
+	Action_NEW_ACCOUNT    Action_Type = 8
+	Action_UPDATE_AUTH    Action_Type = 9
+	Action_DELETE_AUTH    Action_Type = 10
+	Action_LINK_AUTH      Action_Type = 11
+	Action_UNLINK_AUTH    Action_Type = 12
+	Action_VOTE_PRODUCER  Action_Type = 13
+	Action_REG_PROXY      Action_Type = 14
+	Action_CLAIM_REWARDS  Action_Type = 15
+	Action_GENERIC        Action_Type = 16
+	Action_MSIG_PROPOSE   Action_Type = 17
+	Action_MSIG_APPROVE   Action_Type = 18
+	Action_MSIG_UNAPPROVE Action_Type = 19
+	Action_MSIG_CANCEL    Action_Type = 20
+	Action_MSIG_EXEC      Action_Type = 21
 )
 
 var Action_Type_name = map[int32]string{
-	0: "TRANSFER_TOKEN",
-	1: "ISSUE_TOKEN",
-	2: "BUY_RAM_BYTES",
-	3: "BUY_RAM",
-	4: "SELL_RAM",
+	0:  "TRANSFER_TOKEN",
+	1:  "ISSUE_TOKEN",
+	2:  "BUY_RAM_BYTES",
+	3:  "BUY_RAM",
+	4:  "SELL_RAM",
+	5:  "DELEGATE_BW",
+	6:  "UNDELEGATE_BW",
+	7:  "REFUND",
+	8:  "NEW_ACCOUNT",
+	9:  "UPDATE_AUTH",
+	10: "DELETE_AUTH",
+	11: "LINK_AUTH",
+	12: "UNLINK_AUTH",
+	13: "VOTE_PRODUCER",
+	14: "REG_PROXY",
+	15: "CLAIM_REWARDS",
+	16: "GENERIC",
+	17: "MSIG_PROPOSE",
+	18: "MSIG_APPROVE",
+	19: "MSIG_UNAPPROVE",
+	20: "MSIG_CANCEL",
+	21: "MSIG_EXEC",
 }
 var Action_Type_value = map[string]int32{
 	"TRANSFER_TOKEN": 0,
@@ -84,12 +189,115 @@ var Action_Type_value = map[string]int32{
 	"BUY_RAM_BYTES":  2,
 	"BUY_RAM":        3,
 	"SELL_RAM":       4,
+	"DELEGATE_BW":    5,
+	"UNDELEGATE_BW":  6,
+	"REFUND":         7,
+	"NEW_ACCOUNT":    8,
+	"UPDATE_AUTH":    9,
+	"DELETE_AUTH":    10,
+	"LINK_AUTH":      11,
+	"UNLINK_AUTH":    12,
+	"VOTE_PRODUCER":  13,
+	"REG_PROXY":      14,
+	"CLAIM_REWARDS":  15,
+	"GENERIC":        16,
+	"MSIG_PROPOSE":   17,
+	"MSIG_APPROVE":   18,
+	"MSIG_UNAPPROVE": 19,
+	"MSIG_CANCEL":    20,
+	"MSIG_EXEC":      21,
 }
 
 func (x Action_Type) String() string {
 	return proto1.EnumName(Action_Type_name, int32(x))
 }
-func (Action_Type) EnumDescriptor() ([]byte, []int) { return fileDescriptor0, []int{11, 0} }
+func (Action_Type) EnumDescriptor() ([]byte, []int) { return fileDescriptor0, []int{20, 0} }
+
+type Action_Status int32
+
+const (
+	Action_PENDING      Action_Status = 0
+	Action_IRREVERSIBLE Action_Status = 1
+	// action's block is orphaned by fork, action is sent again
+	// with the same cursor and REVERTED status
+	Action_REVERTED Action_Status = 2
+)
+
+var Action_Status_name = map[int32]string{
+	0: "PENDING",
+	1: "IRREVERSIBLE",
+	2: "REVERTED",
+}
+var Action_Status_value = map[string]int32{
+	"PENDING":      0,
+	"IRREVERSIBLE": 1,
+	"REVERTED":     2,
+}
+
+func (x Action_Status) String() string {
+	return proto1.EnumName(Action_Status_name, int32(x))
+}
+func (Action_Status) EnumDescriptor() ([]byte, []int) { return fileDescriptor0, []int{20, 1} }
+
+// status of the transaction receipt, only executed transaction's
+// actions have effect
+type Action_ReceiptStatus int32
+
+const (
+	Action_EXECUTED  Action_ReceiptStatus = 0
+	Action_SOFT_FAIL Action_ReceiptStatus = 1
+	Action_HARD_FAIL Action_ReceiptStatus = 2
+	Action_DELAYED   Action_ReceiptStatus = 3
+	Action_EXPIRED   Action_ReceiptStatus = 4
+)
+
+var Action_ReceiptStatus_name = map[int32]string{
+	0: "EXECUTED",
+	1: "SOFT_FAIL",
+	2: "HARD_FAIL",
+	3: "DELAYED",
+	4: "EXPIRED",
+}
+var Action_ReceiptStatus_value = map[string]int32{
+	"EXECUTED":  0,
+	"SOFT_FAIL": 1,
+	"HARD_FAIL": 2,
+	"DELAYED":   3,
+	"EXPIRED":   4,
+}
+
+func (x Action_ReceiptStatus) String() string {
+	return proto1.EnumName(Action_ReceiptStatus_name, int32(x))
+}
+func (Action_ReceiptStatus) EnumDescriptor() ([]byte, []int) { return fileDescriptor0, []int{20, 2} }
+
+// state of delayed or contract scheduled transaction
+type Action_DeferredStatus int32
+
+const (
+	Action_NOT_DEFERRED       Action_DeferredStatus = 0
+	Action_DEFERRED_PENDING   Action_DeferredStatus = 1
+	Action_DEFERRED_EXECUTED  Action_DeferredStatus = 2
+	Action_DEFERRED_CANCELLED Action_DeferredStatus = 3
+)
+
+var Action_DeferredStatus_name = map[int32]string{
+	0: "NOT_DEFERRED",
+	1: "DEFERRED_PENDING",
+	2: "DEFERRED_EXECUTED",
+	3: "DEFERRED_CANCELLED",
+}
+var Action_DeferredStatus_value = map[string]int32{
+	"NOT_DEFERRED":       0,
+	"DEFERRED_PENDING":   1,
+	"DEFERRED_EXECUTED":  2,
+	"DEFERRED_CANCELLED": 3,
+}
+
+func (x Action_DeferredStatus) String() string {
+	return proto1.EnumName(Action_DeferredStatus_name, int32(x))
+}
+func (Action_DeferredStatus) EnumDescriptor() ([]byte, []int) { return fileDescriptor0, []int{20, 3} }
 
 type Empty struct {
 }
@@ -141,6 +349,8 @@ func (m *ServiceVersion) GetLasttag() string {
 
 type UsersData struct {
 	Map map[string]*AddressExtended `protobuf:"bytes,1,rep,name=map" json:"map,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	// addresses allows to track one account by several wallets
+	Addresses []*WatchAddress `protobuf:"bytes,2,rep,name=addresses" json:"addresses,omitempty"`
 }
 
 func (m *UsersData) Reset()                    { *m = UsersData{} }
@@ -155,6 +365,13 @@ func (m *UsersData) GetMap() map[string]*AddressExtended {
 	return nil
 }
 
+func (m *UsersData) GetAddresses() []*WatchAddress {
+	if m != nil {
+		return m.Addresses
+	}
+	return nil
+}
+
 type AddressExtended struct {
 	UserID       string `protobuf:"bytes,1,opt,name=UserID,json=userID" json:"UserID,omitempty"`
 	WalletIndex  int32  `protobuf:"varint,2,opt,name=WalletIndex,json=walletIndex" json:"WalletIndex,omitempty"`
@@ -243,6 +460,126 @@ func (m *WatchAddress) GetAddressIndex() int32 {
 	return 0
 }
 
+type UserID struct {
+	UserID string `protobuf:"bytes,1,opt,name=userID" json:"userID,omitempty"`
+}
+
+func (m *UserID) Reset()                    { *m = UserID{} }
+func (m *UserID) String() string            { return proto1.CompactTextString(m) }
+func (*UserID) ProtoMessage()               {}
+func (*UserID) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{6} }
+
+func (m *UserID) GetUserID() string {
+	if m != nil {
+		return m.UserID
+	}
+	return ""
+}
+
+type NewTxReq struct {
+	StartBlockNum    uint32 `protobuf:"varint,1,opt,name=start_block_num,json=startBlockNum" json:"start_block_num,omitempty"`
+	StartBlockId     string `protobuf:"bytes,2,opt,name=start_block_id,json=startBlockId" json:"start_block_id,omitempty"`
+	Cursor           string `protobuf:"bytes,3,opt,name=cursor" json:"cursor,omitempty"`
+	IrreversibleOnly bool   `protobuf:"varint,4,opt,name=irreversible_only,json=irreversibleOnly" json:"irreversible_only,omitempty"`
+}
+
+func (m *NewTxReq) Reset()                    { *m = NewTxReq{} }
+func (m *NewTxReq) String() string            { return proto1.CompactTextString(m) }
+func (*NewTxReq) ProtoMessage()               {}
+func (*NewTxReq) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{7} }
+
+func (m *NewTxReq) GetStartBlockNum() uint32 {
+	if m != nil {
+		return m.StartBlockNum
+	}
+	return 0
+}
+
+func (m *NewTxReq) GetStartBlockId() string {
+	if m != nil {
+		return m.StartBlockId
+	}
+	return ""
+}
+
+func (m *NewTxReq) GetCursor() string {
+	if m != nil {
+		return m.Cursor
+	}
+	return ""
+}
+
+func (m *NewTxReq) GetIrreversibleOnly() bool {
+	if m != nil {
+		return m.IrreversibleOnly
+	}
+	return false
+}
+
+type NewTxStream struct {
+	Name    string `protobuf:"bytes,1,opt,name=name" json:"name,omitempty"`
+	Lag     uint64 `protobuf:"varint,2,opt,name=lag" json:"lag,omitempty"`
+	MaxLag  uint64 `protobuf:"varint,3,opt,name=max_lag,json=maxLag" json:"max_lag,omitempty"`
+	Queued  uint64 `protobuf:"varint,4,opt,name=queued" json:"queued,omitempty"`
+	Dropped uint64 `protobuf:"varint,5,opt,name=dropped" json:"dropped,omitempty"`
+}
+
+func (m *NewTxStream) Reset()                    { *m = NewTxStream{} }
+func (m *NewTxStream) String() string            { return proto1.CompactTextString(m) }
+func (*NewTxStream) ProtoMessage()               {}
+func (*NewTxStream) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{8} }
+
+func (m *NewTxStream) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *NewTxStream) GetLag() uint64 {
+	if m != nil {
+		return m.Lag
+	}
+	return 0
+}
+
+func (m *NewTxStream) GetMaxLag() uint64 {
+	if m != nil {
+		return m.MaxLag
+	}
+	return 0
+}
+
+func (m *NewTxStream) GetQueued() uint64 {
+	if m != nil {
+		return m.Queued
+	}
+	return 0
+}
+
+func (m *NewTxStream) GetDropped() uint64 {
+	if m != nil {
+		return m.Dropped
+	}
+	return 0
+}
+
+type NewTxStreamsList struct {
+	Streams []*NewTxStream `protobuf:"bytes,1,rep,name=streams" json:"streams,omitempty"`
+}
+
+func (m *NewTxStreamsList) Reset()                    { *m = NewTxStreamsList{} }
+func (m *NewTxStreamsList) String() string            { return proto1.CompactTextString(m) }
+func (*NewTxStreamsList) ProtoMessage()               {}
+func (*NewTxStreamsList) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{9} }
+
+func (m *NewTxStreamsList) GetStreams() []*NewTxStream {
+	if m != nil {
+		return m.Streams
+	}
+	return nil
+}
+
 type BlockHeight struct {
 	HeadBlockNum  uint32 `protobuf:"varint,1,opt,name=head_block_num,json=headBlockNum" json:"head_block_num,omitempty"`
 	HeadBlockId   string `protobuf:"bytes,2,opt,name=head_block_id,json=headBlockId" json:"head_block_id,omitempty"`
@@ -252,7 +589,7 @@ type BlockHeight struct {
 func (m *BlockHeight) Reset()                    { *m = BlockHeight{} }
 func (m *BlockHeight) String() string            { return proto1.CompactTextString(m) }
 func (*BlockHeight) ProtoMessage()               {}
-func (*BlockHeight) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{6} }
+func (*BlockHeight) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{10} }
 
 func (m *BlockHeight) GetHeadBlockNum() uint32 {
 	if m != nil {
@@ -276,13 +613,17 @@ func (m *BlockHeight) GetHeadBlockTime() int64 {
 }
 
 type AddressToResync struct {
-	Address string `protobuf:"bytes,1,opt,name=address" json:"address,omitempty"`
+	Address      string                 `protobuf:"bytes,1,opt,name=address" json:"address,omitempty"`
+	StartBlock   uint32                 `protobuf:"varint,2,opt,name=start_block,json=startBlock" json:"start_block,omitempty"`
+	EndBlock     uint32                 `protobuf:"varint,3,opt,name=end_block,json=endBlock" json:"end_block,omitempty"`
+	FromCreation bool                   `protobuf:"varint,4,opt,name=from_creation,json=fromCreation" json:"from_creation,omitempty"`
+	Source       AddressToResync_Source `protobuf:"varint,5,opt,name=source,enum=proto.AddressToResync_Source" json:"source,omitempty"`
 }
 
 func (m *AddressToResync) Reset()                    { *m = AddressToResync{} }
 func (m *AddressToResync) String() string            { return proto1.CompactTextString(m) }
 func (*AddressToResync) ProtoMessage()               {}
-func (*AddressToResync) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{7} }
+func (*AddressToResync) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{11} }
 
 func (m *AddressToResync) GetAddress() string {
 	if m != nil {
@@ -291,6 +632,234 @@ func (m *AddressToResync) GetAddress() string {
 	return ""
 }
 
+func (m *AddressToResync) GetStartBlock() uint32 {
+	if m != nil {
+		return m.StartBlock
+	}
+	return 0
+}
+
+func (m *AddressToResync) GetEndBlock() uint32 {
+	if m != nil {
+		return m.EndBlock
+	}
+	return 0
+}
+
+func (m *AddressToResync) GetFromCreation() bool {
+	if m != nil {
+		return m.FromCreation
+	}
+	return false
+}
+
+func (m *AddressToResync) GetSource() AddressToResync_Source {
+	if m != nil {
+		return m.Source
+	}
+	return AddressToResync_AUTO
+}
+
+type ResyncJobID struct {
+	JobID string `protobuf:"bytes,1,opt,name=jobID" json:"jobID,omitempty"`
+}
+
+func (m *ResyncJobID) Reset()                    { *m = ResyncJobID{} }
+func (m *ResyncJobID) String() string            { return proto1.CompactTextString(m) }
+func (*ResyncJobID) ProtoMessage()               {}
+func (*ResyncJobID) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{12} }
+
+func (m *ResyncJobID) GetJobID() string {
+	if m != nil {
+		return m.JobID
+	}
+	return ""
+}
+
+type ResyncProgress struct {
+	JobID        string                `protobuf:"bytes,1,opt,name=jobID" json:"jobID,omitempty"`
+	Address      string                `protobuf:"bytes,2,opt,name=address" json:"address,omitempty"`
+	Status       ResyncProgress_Status `protobuf:"varint,3,opt,name=status,enum=proto.ResyncProgress_Status" json:"status,omitempty"`
+	StartBlock   uint32                `protobuf:"varint,4,opt,name=start_block,json=startBlock" json:"start_block,omitempty"`
+	CurrentBlock uint32                `protobuf:"varint,5,opt,name=current_block,json=currentBlock" json:"current_block,omitempty"`
+	EndBlock     uint32                `protobuf:"varint,6,opt,name=end_block,json=endBlock" json:"end_block,omitempty"`
+	ActionsFound uint64                `protobuf:"varint,7,opt,name=actions_found,json=actionsFound" json:"actions_found,omitempty"`
+	Error        string                `protobuf:"bytes,8,opt,name=error" json:"error,omitempty"`
+}
+
+func (m *ResyncProgress) Reset()                    { *m = ResyncProgress{} }
+func (m *ResyncProgress) String() string            { return proto1.CompactTextString(m) }
+func (*ResyncProgress) ProtoMessage()               {}
+func (*ResyncProgress) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{13} }
+
+func (m *ResyncProgress) GetJobID() string {
+	if m != nil {
+		return m.JobID
+	}
+	return ""
+}
+
+func (m *ResyncProgress) GetAddress() string {
+	if m != nil {
+		return m.Address
+	}
+	return ""
+}
+
+func (m *ResyncProgress) GetStatus() ResyncProgress_Status {
+	if m != nil {
+		return m.Status
+	}
+	return ResyncProgress_RUNNING
+}
+
+func (m *ResyncProgress) GetStartBlock() uint32 {
+	if m != nil {
+		return m.StartBlock
+	}
+	return 0
+}
+
+func (m *ResyncProgress) GetCurrentBlock() uint32 {
+	if m != nil {
+		return m.CurrentBlock
+	}
+	return 0
+}
+
+func (m *ResyncProgress) GetEndBlock() uint32 {
+	if m != nil {
+		return m.EndBlock
+	}
+	return 0
+}
+
+func (m *ResyncProgress) GetActionsFound() uint64 {
+	if m != nil {
+		return m.ActionsFound
+	}
+	return 0
+}
+
+func (m *ResyncProgress) GetError() string {
+	if m != nil {
+		return m.Error
+	}
+	return ""
+}
+
+type ResyncJobsList struct {
+	Jobs []*ResyncProgress `protobuf:"bytes,1,rep,name=jobs" json:"jobs,omitempty"`
+}
+
+func (m *ResyncJobsList) Reset()                    { *m = ResyncJobsList{} }
+func (m *ResyncJobsList) String() string            { return proto1.CompactTextString(m) }
+func (*ResyncJobsList) ProtoMessage()               {}
+func (*ResyncJobsList) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{14} }
+
+func (m *ResyncJobsList) GetJobs() []*ResyncProgress {
+	if m != nil {
+		return m.Jobs
+	}
+	return nil
+}
+
+type AccountHistoryReq struct {
+	Address    string        `protobuf:"bytes,1,opt,name=address" json:"address,omitempty"`
+	Types      []Action_Type `protobuf:"varint,2,rep,packed,name=types,enum=proto.Action_Type" json:"types,omitempty"`
+	Symbol     string        `protobuf:"bytes,3,opt,name=symbol" json:"symbol,omitempty"`
+	Contract   string        `protobuf:"bytes,4,opt,name=contract" json:"contract,omitempty"`
+	StartBlock uint32        `protobuf:"varint,5,opt,name=start_block,json=startBlock" json:"start_block,omitempty"`
+	EndBlock   uint32        `protobuf:"varint,6,opt,name=end_block,json=endBlock" json:"end_block,omitempty"`
+	Limit      uint32        `protobuf:"varint,7,opt,name=limit" json:"limit,omitempty"`
+	Cursor     string        `protobuf:"bytes,8,opt,name=cursor" json:"cursor,omitempty"`
+}
+
+func (m *AccountHistoryReq) Reset()                    { *m = AccountHistoryReq{} }
+func (m *AccountHistoryReq) String() string            { return proto1.CompactTextString(m) }
+func (*AccountHistoryReq) ProtoMessage()               {}
+func (*AccountHistoryReq) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{15} }
+
+func (m *AccountHistoryReq) GetAddress() string {
+	if m != nil {
+		return m.Address
+	}
+	return ""
+}
+
+func (m *AccountHistoryReq) GetTypes() []Action_Type {
+	if m != nil {
+		return m.Types
+	}
+	return nil
+}
+
+func (m *AccountHistoryReq) GetSymbol() string {
+	if m != nil {
+		return m.Symbol
+	}
+	return ""
+}
+
+func (m *AccountHistoryReq) GetContract() string {
+	if m != nil {
+		return m.Contract
+	}
+	return ""
+}
+
+func (m *AccountHistoryReq) GetStartBlock() uint32 {
+	if m != nil {
+		return m.StartBlock
+	}
+	return 0
+}
+
+func (m *AccountHistoryReq) GetEndBlock() uint32 {
+	if m != nil {
+		return m.EndBlock
+	}
+	return 0
+}
+
+func (m *AccountHistoryReq) GetLimit() uint32 {
+	if m != nil {
+		return m.Limit
+	}
+	return 0
+}
+
+func (m *AccountHistoryReq) GetCursor() string {
+	if m != nil {
+		return m.Cursor
+	}
+	return ""
+}
+
+type AccountHistory struct {
+	Actions    []*Action `protobuf:"bytes,1,rep,name=actions" json:"actions,omitempty"`
+	NextCursor string    `protobuf:"bytes,2,opt,name=next_cursor,json=nextCursor" json:"next_cursor,omitempty"`
+}
+
+func (m *AccountHistory) Reset()                    { *m = AccountHistory{} }
+func (m *AccountHistory) String() string            { return proto1.CompactTextString(m) }
+func (*AccountHistory) ProtoMessage()               {}
+func (*AccountHistory) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{16} }
+
+func (m *AccountHistory) GetActions() []*Action {
+	if m != nil {
+		return m.Actions
+	}
+	return nil
+}
+
+func (m *AccountHistory) GetNextCursor() string {
+	if m != nil {
+		return m.NextCursor
+	}
+	return ""
+}
+
 type Balance struct {
 	Balance string `protobuf:"bytes,1,opt,name=Balance,json=balance" json:"Balance,omitempty"`
 }
@@ -298,7 +867,7 @@ type Balance struct {
 func (m *Balance) Reset()                    { *m = Balance{} }
 func (m *Balance) String() string            { return proto1.CompactTextString(m) }
 func (*Balance) ProtoMessage()               {}
-func (*Balance) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{8} }
+func (*Balance) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{17} }
 
 func (m *Balance) GetBalance() string {
 	if m != nil {
@@ -314,7 +883,7 @@ type RawTx struct {
 func (m *RawTx) Reset()                    { *m = RawTx{} }
 func (m *RawTx) String() string            { return proto1.CompactTextString(m) }
 func (*RawTx) ProtoMessage()               {}
-func (*RawTx) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{9} }
+func (*RawTx) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{18} }
 
 func (m *RawTx) GetTransaction() []byte {
 	if m != nil {
@@ -330,7 +899,7 @@ type SendTxResp struct {
 func (m *SendTxResp) Reset()                    { *m = SendTxResp{} }
 func (m *SendTxResp) String() string            { return proto1.CompactTextString(m) }
 func (*SendTxResp) ProtoMessage()               {}
-func (*SendTxResp) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{10} }
+func (*SendTxResp) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{19} }
 
 func (m *SendTxResp) GetTransactionId() string {
 	if m != nil {
@@ -340,25 +909,49 @@ func (m *SendTxResp) GetTransactionId() string {
 }
 
 type Action struct {
-	UserID        string      `protobuf:"bytes,1,opt,name=UserID,json=userID" json:"UserID,omitempty"`
-	WalletIndex   int32       `protobuf:"varint,2,opt,name=WalletIndex,json=walletIndex" json:"WalletIndex,omitempty"`
-	AddressIndex  int32       `protobuf:"varint,3,opt,name=AddressIndex,json=addressIndex" json:"AddressIndex,omitempty"`
-	Type          Action_Type `protobuf:"varint,4,opt,name=type,enum=proto.Action_Type" json:"type,omitempty"`
-	From          string      `protobuf:"bytes,5,opt,name=from" json:"from,omitempty"`
-	To            string      `protobuf:"bytes,6,opt,name=to" json:"to,omitempty"`
-	Amount        *Asset      `protobuf:"bytes,7,opt,name=amount" json:"amount,omitempty"`
-	Memo          string      `protobuf:"bytes,8,opt,name=memo" json:"memo,omitempty"`
-	Resync        bool        `protobuf:"varint,9,opt,name=resync" json:"resync,omitempty"`
-	TransactionId []byte      `protobuf:"bytes,10,opt,name=transaction_id,json=transactionId,proto3" json:"transaction_id,omitempty"`
-	ActionIndex   int64       `protobuf:"varint,11,opt,name=action_index,json=actionIndex" json:"action_index,omitempty"`
-	Address       string      `protobuf:"bytes,12,opt,name=address" json:"address,omitempty"`
-	BlockNum      uint32      `protobuf:"varint,13,opt,name=block_num,json=blockNum" json:"block_num,omitempty"`
+	UserID        string        `protobuf:"bytes,1,opt,name=UserID,json=userID" json:"UserID,omitempty"`
+	WalletIndex   int32         `protobuf:"varint,2,opt,name=WalletIndex,json=walletIndex" json:"WalletIndex,omitempty"`
+	AddressIndex  int32         `protobuf:"varint,3,opt,name=AddressIndex,json=addressIndex" json:"AddressIndex,omitempty"`
+	Type          Action_Type   `protobuf:"varint,4,opt,name=type,enum=proto.Action_Type" json:"type,omitempty"`
+	From          string        `protobuf:"bytes,5,opt,name=from" json:"from,omitempty"`
+	To            string        `protobuf:"bytes,6,opt,name=to" json:"to,omitempty"`
+	Amount        *Asset        `protobuf:"bytes,7,opt,name=amount" json:"amount,omitempty"`
+	Memo          string        `protobuf:"bytes,8,opt,name=memo" json:"memo,omitempty"`
+	Resync        bool          `protobuf:"varint,9,opt,name=resync" json:"resync,omitempty"`
+	TransactionId []byte        `protobuf:"bytes,10,opt,name=transaction_id,json=transactionId,proto3" json:"transaction_id,omitempty"`
+	ActionIndex   int64         `protobuf:"varint,11,opt,name=action_index,json=actionIndex" json:"action_index,omitempty"`
+	Address       string        `protobuf:"bytes,12,opt,name=address" json:"address,omitempty"`
+	BlockNum      uint32        `protobuf:"varint,13,opt,name=block_num,json=blockNum" json:"block_num,omitempty"`
+	Cursor        string        `protobuf:"bytes,14,opt,name=cursor" json:"cursor,omitempty"`
+	Status        Action_Status `protobuf:"varint,15,opt,name=status,enum=proto.Action_Status" json:"status,omitempty"`
+	Contract      string        `protobuf:"bytes,16,opt,name=contract" json:"contract,omitempty"`
+	Cpu           *Asset        `protobuf:"bytes,17,opt,name=cpu" json:"cpu,omitempty"`
+	Net           *Asset        `protobuf:"bytes,18,opt,name=net" json:"net,omitempty"`
+	Transfer      bool          `protobuf:"varint,19,opt,name=transfer" json:"transfer,omitempty"`
+	// new account's owner and active, updated or deleted permission
+	Permissions       []*Permission         `protobuf:"bytes,20,rep,name=permissions" json:"permissions,omitempty"`
+	Link              *PermissionLink       `protobuf:"bytes,21,opt,name=link" json:"link,omitempty"`
+	Proxy             string                `protobuf:"bytes,22,opt,name=proxy" json:"proxy,omitempty"`
+	Producers         []string              `protobuf:"bytes,23,rep,name=producers" json:"producers,omitempty"`
+	IsProxy           bool                  `protobuf:"varint,24,opt,name=is_proxy,json=isProxy" json:"is_proxy,omitempty"`
+	Name              string                `protobuf:"bytes,25,opt,name=name" json:"name,omitempty"`
+	Authorization     []*PermissionLevel    `protobuf:"bytes,26,rep,name=authorization" json:"authorization,omitempty"`
+	Data              string                `protobuf:"bytes,27,opt,name=data" json:"data,omitempty"`
+	InlineDepth       uint32                `protobuf:"varint,28,opt,name=inline_depth,json=inlineDepth" json:"inline_depth,omitempty"`
+	ParentActionIndex int64                 `protobuf:"varint,29,opt,name=parent_action_index,json=parentActionIndex" json:"parent_action_index,omitempty"`
+	Receiver          string                `protobuf:"bytes,30,opt,name=receiver" json:"receiver,omitempty"`
+	ReceiptStatus     Action_ReceiptStatus  `protobuf:"varint,31,opt,name=receipt_status,json=receiptStatus,enum=proto.Action_ReceiptStatus" json:"receipt_status,omitempty"`
+	CpuUsageUs        uint32                `protobuf:"varint,32,opt,name=cpu_usage_us,json=cpuUsageUs" json:"cpu_usage_us,omitempty"`
+	NetUsageWords     uint32                `protobuf:"varint,33,opt,name=net_usage_words,json=netUsageWords" json:"net_usage_words,omitempty"`
+	DeferredStatus    Action_DeferredStatus `protobuf:"varint,34,opt,name=deferred_status,json=deferredStatus,enum=proto.Action_DeferredStatus" json:"deferred_status,omitempty"`
+	ContextFree       bool                  `protobuf:"varint,35,opt,name=context_free,json=contextFree" json:"context_free,omitempty"`
+	Proposal          *Proposal             `protobuf:"bytes,36,opt,name=proposal" json:"proposal,omitempty"`
 }
 
 func (m *Action) Reset()                    { *m = Action{} }
 func (m *Action) String() string            { return proto1.CompactTextString(m) }
 func (*Action) ProtoMessage()               {}
-func (*Action) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{11} }
+func (*Action) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{20} }
 
 func (m *Action) GetUserID() string {
 	if m != nil {
@@ -388,67 +981,510 @@ func (m *Action) GetType() Action_Type {
 	return Action_TRANSFER_TOKEN
 }
 
-func (m *Action) GetFrom() string {
+func (m *Action) GetFrom() string {
+	if m != nil {
+		return m.From
+	}
+	return ""
+}
+
+func (m *Action) GetTo() string {
+	if m != nil {
+		return m.To
+	}
+	return ""
+}
+
+func (m *Action) GetAmount() *Asset {
+	if m != nil {
+		return m.Amount
+	}
+	return nil
+}
+
+func (m *Action) GetMemo() string {
+	if m != nil {
+		return m.Memo
+	}
+	return ""
+}
+
+func (m *Action) GetResync() bool {
+	if m != nil {
+		return m.Resync
+	}
+	return false
+}
+
+func (m *Action) GetTransactionId() []byte {
+	if m != nil {
+		return m.TransactionId
+	}
+	return nil
+}
+
+func (m *Action) GetActionIndex() int64 {
+	if m != nil {
+		return m.ActionIndex
+	}
+	return 0
+}
+
+func (m *Action) GetAddress() string {
+	if m != nil {
+		return m.Address
+	}
+	return ""
+}
+
+func (m *Action) GetBlockNum() uint32 {
+	if m != nil {
+		return m.BlockNum
+	}
+	return 0
+}
+
+func (m *Action) GetCursor() string {
+	if m != nil {
+		return m.Cursor
+	}
+	return ""
+}
+
+func (m *Action) GetStatus() Action_Status {
+	if m != nil {
+		return m.Status
+	}
+	return Action_PENDING
+}
+
+func (m *Action) GetContract() string {
+	if m != nil {
+		return m.Contract
+	}
+	return ""
+}
+
+func (m *Action) GetCpu() *Asset {
+	if m != nil {
+		return m.Cpu
+	}
+	return nil
+}
+
+func (m *Action) GetNet() *Asset {
+	if m != nil {
+		return m.Net
+	}
+	return nil
+}
+
+func (m *Action) GetTransfer() bool {
+	if m != nil {
+		return m.Transfer
+	}
+	return false
+}
+
+func (m *Action) GetPermissions() []*Permission {
+	if m != nil {
+		return m.Permissions
+	}
+	return nil
+}
+
+func (m *Action) GetLink() *PermissionLink {
+	if m != nil {
+		return m.Link
+	}
+	return nil
+}
+
+func (m *Action) GetProxy() string {
+	if m != nil {
+		return m.Proxy
+	}
+	return ""
+}
+
+func (m *Action) GetProducers() []string {
+	if m != nil {
+		return m.Producers
+	}
+	return nil
+}
+
+func (m *Action) GetIsProxy() bool {
+	if m != nil {
+		return m.IsProxy
+	}
+	return false
+}
+
+func (m *Action) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *Action) GetAuthorization() []*PermissionLevel {
+	if m != nil {
+		return m.Authorization
+	}
+	return nil
+}
+
+func (m *Action) GetData() string {
+	if m != nil {
+		return m.Data
+	}
+	return ""
+}
+
+func (m *Action) GetInlineDepth() uint32 {
+	if m != nil {
+		return m.InlineDepth
+	}
+	return 0
+}
+
+func (m *Action) GetParentActionIndex() int64 {
+	if m != nil {
+		return m.ParentActionIndex
+	}
+	return 0
+}
+
+func (m *Action) GetReceiver() string {
+	if m != nil {
+		return m.Receiver
+	}
+	return ""
+}
+
+func (m *Action) GetReceiptStatus() Action_ReceiptStatus {
+	if m != nil {
+		return m.ReceiptStatus
+	}
+	return Action_EXECUTED
+}
+
+func (m *Action) GetCpuUsageUs() uint32 {
+	if m != nil {
+		return m.CpuUsageUs
+	}
+	return 0
+}
+
+func (m *Action) GetNetUsageWords() uint32 {
+	if m != nil {
+		return m.NetUsageWords
+	}
+	return 0
+}
+
+func (m *Action) GetDeferredStatus() Action_DeferredStatus {
+	if m != nil {
+		return m.DeferredStatus
+	}
+	return Action_NOT_DEFERRED
+}
+
+func (m *Action) GetContextFree() bool {
+	if m != nil {
+		return m.ContextFree
+	}
+	return false
+}
+
+func (m *Action) GetProposal() *Proposal {
+	if m != nil {
+		return m.Proposal
+	}
+	return nil
+}
+
+type Proposal struct {
+	Proposer     string `protobuf:"bytes,1,opt,name=proposer" json:"proposer,omitempty"`
+	ProposalName string `protobuf:"bytes,2,opt,name=proposal_name,json=proposalName" json:"proposal_name,omitempty"`
+	// requested approvals, empty if proposal is made before service start
+	Requested []*PermissionLevel `protobuf:"bytes,3,rep,name=requested" json:"requested,omitempty"`
+	// proposed transaction's actions, empty if proposal is unknown
+	Actions []*ProposedAction `protobuf:"bytes,4,rep,name=actions" json:"actions,omitempty"`
+	Level   *PermissionLevel  `protobuf:"bytes,5,opt,name=level" json:"level,omitempty"`
+}
+
+func (m *Proposal) Reset()                    { *m = Proposal{} }
+func (m *Proposal) String() string            { return proto1.CompactTextString(m) }
+func (*Proposal) ProtoMessage()               {}
+func (*Proposal) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{21} }
+
+func (m *Proposal) GetProposer() string {
+	if m != nil {
+		return m.Proposer
+	}
+	return ""
+}
+
+func (m *Proposal) GetProposalName() string {
+	if m != nil {
+		return m.ProposalName
+	}
+	return ""
+}
+
+func (m *Proposal) GetRequested() []*PermissionLevel {
+	if m != nil {
+		return m.Requested
+	}
+	return nil
+}
+
+func (m *Proposal) GetActions() []*ProposedAction {
+	if m != nil {
+		return m.Actions
+	}
+	return nil
+}
+
+func (m *Proposal) GetLevel() *PermissionLevel {
+	if m != nil {
+		return m.Level
+	}
+	return nil
+}
+
+type ProposedAction struct {
+	Contract      string             `protobuf:"bytes,1,opt,name=contract" json:"contract,omitempty"`
+	Name          string             `protobuf:"bytes,2,opt,name=name" json:"name,omitempty"`
+	Authorization []*PermissionLevel `protobuf:"bytes,3,rep,name=authorization" json:"authorization,omitempty"`
+	Data          string             `protobuf:"bytes,4,opt,name=data" json:"data,omitempty"`
+}
+
+func (m *ProposedAction) Reset()                    { *m = ProposedAction{} }
+func (m *ProposedAction) String() string            { return proto1.CompactTextString(m) }
+func (*ProposedAction) ProtoMessage()               {}
+func (*ProposedAction) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{22} }
+
+func (m *ProposedAction) GetContract() string {
+	if m != nil {
+		return m.Contract
+	}
+	return ""
+}
+
+func (m *ProposedAction) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *ProposedAction) GetAuthorization() []*PermissionLevel {
+	if m != nil {
+		return m.Authorization
+	}
+	return nil
+}
+
+func (m *ProposedAction) GetData() string {
+	if m != nil {
+		return m.Data
+	}
+	return ""
+}
+
+type PermissionLevel struct {
+	Actor      string `protobuf:"bytes,1,opt,name=actor" json:"actor,omitempty"`
+	Permission string `protobuf:"bytes,2,opt,name=permission" json:"permission,omitempty"`
+}
+
+func (m *PermissionLevel) Reset()                    { *m = PermissionLevel{} }
+func (m *PermissionLevel) String() string            { return proto1.CompactTextString(m) }
+func (*PermissionLevel) ProtoMessage()               {}
+func (*PermissionLevel) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{23} }
+
+func (m *PermissionLevel) GetActor() string {
+	if m != nil {
+		return m.Actor
+	}
+	return ""
+}
+
+func (m *PermissionLevel) GetPermission() string {
+	if m != nil {
+		return m.Permission
+	}
+	return ""
+}
+
+type Permission struct {
+	Name      string                   `protobuf:"bytes,1,opt,name=name" json:"name,omitempty"`
+	Parent    string                   `protobuf:"bytes,2,opt,name=parent" json:"parent,omitempty"`
+	Threshold uint32                   `protobuf:"varint,3,opt,name=threshold" json:"threshold,omitempty"`
+	Keys      []*KeyWeight             `protobuf:"bytes,4,rep,name=keys" json:"keys,omitempty"`
+	Accounts  []*PermissionLevelWeight `protobuf:"bytes,5,rep,name=accounts" json:"accounts,omitempty"`
+	Waits     []*WaitWeight            `protobuf:"bytes,6,rep,name=waits" json:"waits,omitempty"`
+}
+
+func (m *Permission) Reset()                    { *m = Permission{} }
+func (m *Permission) String() string            { return proto1.CompactTextString(m) }
+func (*Permission) ProtoMessage()               {}
+func (*Permission) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{24} }
+
+func (m *Permission) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *Permission) GetParent() string {
+	if m != nil {
+		return m.Parent
+	}
+	return ""
+}
+
+func (m *Permission) GetThreshold() uint32 {
+	if m != nil {
+		return m.Threshold
+	}
+	return 0
+}
+
+func (m *Permission) GetKeys() []*KeyWeight {
+	if m != nil {
+		return m.Keys
+	}
+	return nil
+}
+
+func (m *Permission) GetAccounts() []*PermissionLevelWeight {
+	if m != nil {
+		return m.Accounts
+	}
+	return nil
+}
+
+func (m *Permission) GetWaits() []*WaitWeight {
+	if m != nil {
+		return m.Waits
+	}
+	return nil
+}
+
+type KeyWeight struct {
+	Key    string `protobuf:"bytes,1,opt,name=key" json:"key,omitempty"`
+	Weight uint32 `protobuf:"varint,2,opt,name=weight" json:"weight,omitempty"`
+}
+
+func (m *KeyWeight) Reset()                    { *m = KeyWeight{} }
+func (m *KeyWeight) String() string            { return proto1.CompactTextString(m) }
+func (*KeyWeight) ProtoMessage()               {}
+func (*KeyWeight) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{25} }
+
+func (m *KeyWeight) GetKey() string {
+	if m != nil {
+		return m.Key
+	}
+	return ""
+}
+
+func (m *KeyWeight) GetWeight() uint32 {
+	if m != nil {
+		return m.Weight
+	}
+	return 0
+}
+
+type PermissionLevelWeight struct {
+	Actor      string `protobuf:"bytes,1,opt,name=actor" json:"actor,omitempty"`
+	Permission string `protobuf:"bytes,2,opt,name=permission" json:"permission,omitempty"`
+	Weight     uint32 `protobuf:"varint,3,opt,name=weight" json:"weight,omitempty"`
+}
+
+func (m *PermissionLevelWeight) Reset()                    { *m = PermissionLevelWeight{} }
+func (m *PermissionLevelWeight) String() string            { return proto1.CompactTextString(m) }
+func (*PermissionLevelWeight) ProtoMessage()               {}
+func (*PermissionLevelWeight) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{26} }
+
+func (m *PermissionLevelWeight) GetActor() string {
 	if m != nil {
-		return m.From
+		return m.Actor
 	}
 	return ""
 }
 
-func (m *Action) GetTo() string {
+func (m *PermissionLevelWeight) GetPermission() string {
 	if m != nil {
-		return m.To
+		return m.Permission
 	}
 	return ""
 }
 
-func (m *Action) GetAmount() *Asset {
+func (m *PermissionLevelWeight) GetWeight() uint32 {
 	if m != nil {
-		return m.Amount
+		return m.Weight
 	}
-	return nil
+	return 0
 }
 
-func (m *Action) GetMemo() string {
-	if m != nil {
-		return m.Memo
-	}
-	return ""
+type WaitWeight struct {
+	WaitSec uint32 `protobuf:"varint,1,opt,name=wait_sec,json=waitSec" json:"wait_sec,omitempty"`
+	Weight  uint32 `protobuf:"varint,2,opt,name=weight" json:"weight,omitempty"`
 }
 
-func (m *Action) GetResync() bool {
+func (m *WaitWeight) Reset()                    { *m = WaitWeight{} }
+func (m *WaitWeight) String() string            { return proto1.CompactTextString(m) }
+func (*WaitWeight) ProtoMessage()               {}
+func (*WaitWeight) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{27} }
+
+func (m *WaitWeight) GetWaitSec() uint32 {
 	if m != nil {
-		return m.Resync
+		return m.WaitSec
 	}
-	return false
+	return 0
 }
 
-func (m *Action) GetTransactionId() []byte {
+func (m *WaitWeight) GetWeight() uint32 {
 	if m != nil {
-		return m.TransactionId
+		return m.Weight
 	}
-	return nil
+	return 0
 }
 
-func (m *Action) GetActionIndex() int64 {
+type PermissionLink struct {
+	Code        string `protobuf:"bytes,1,opt,name=code" json:"code,omitempty"`
+	Type        string `protobuf:"bytes,2,opt,name=type" json:"type,omitempty"`
+	Requirement string `protobuf:"bytes,3,opt,name=requirement" json:"requirement,omitempty"`
+}
+
+func (m *PermissionLink) Reset()                    { *m = PermissionLink{} }
+func (m *PermissionLink) String() string            { return proto1.CompactTextString(m) }
+func (*PermissionLink) ProtoMessage()               {}
+func (*PermissionLink) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{28} }
+
+func (m *PermissionLink) GetCode() string {
 	if m != nil {
-		return m.ActionIndex
+		return m.Code
 	}
-	return 0
+	return ""
 }
 
-func (m *Action) GetAddress() string {
+func (m *PermissionLink) GetType() string {
 	if m != nil {
-		return m.Address
+		return m.Type
 	}
 	return ""
 }
 
-func (m *Action) GetBlockNum() uint32 {
+func (m *PermissionLink) GetRequirement() string {
 	if m != nil {
-		return m.BlockNum
+		return m.Requirement
 	}
-	return 0
+	return ""
 }
 
 type BalanceReq struct {
@@ -460,7 +1496,7 @@ type BalanceReq struct {
 func (m *BalanceReq) Reset()                    { *m = BalanceReq{} }
 func (m *BalanceReq) String() string            { return proto1.CompactTextString(m) }
 func (*BalanceReq) ProtoMessage()               {}
-func (*BalanceReq) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{12} }
+func (*BalanceReq) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{29} }
 
 func (m *BalanceReq) GetAccount() string {
 	if m != nil {
@@ -490,7 +1526,7 @@ type Account struct {
 func (m *Account) Reset()                    { *m = Account{} }
 func (m *Account) String() string            { return proto1.CompactTextString(m) }
 func (*Account) ProtoMessage()               {}
-func (*Account) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{13} }
+func (*Account) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{30} }
 
 func (m *Account) GetName() string {
 	if m != nil {
@@ -508,7 +1544,7 @@ type Asset struct {
 func (m *Asset) Reset()                    { *m = Asset{} }
 func (m *Asset) String() string            { return proto1.CompactTextString(m) }
 func (*Asset) ProtoMessage()               {}
-func (*Asset) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{14} }
+func (*Asset) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{31} }
 
 func (m *Asset) GetAmount() int64 {
 	if m != nil {
@@ -543,7 +1579,7 @@ type AccountCreateReq struct {
 func (m *AccountCreateReq) Reset()                    { *m = AccountCreateReq{} }
 func (m *AccountCreateReq) String() string            { return proto1.CompactTextString(m) }
 func (*AccountCreateReq) ProtoMessage()               {}
-func (*AccountCreateReq) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{15} }
+func (*AccountCreateReq) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{32} }
 
 func (m *AccountCreateReq) GetName() string {
 	if m != nil {
@@ -595,7 +1631,7 @@ type AccountInfo struct {
 func (m *AccountInfo) Reset()                    { *m = AccountInfo{} }
 func (m *AccountInfo) String() string            { return proto1.CompactTextString(m) }
 func (*AccountInfo) ProtoMessage()               {}
-func (*AccountInfo) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{16} }
+func (*AccountInfo) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{33} }
 
 func (m *AccountInfo) GetExist() bool {
 	if m != nil {
@@ -618,7 +1654,7 @@ type RAMPrice struct {
 func (m *RAMPrice) Reset()                    { *m = RAMPrice{} }
 func (m *RAMPrice) String() string            { return proto1.CompactTextString(m) }
 func (*RAMPrice) ProtoMessage()               {}
-func (*RAMPrice) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{17} }
+func (*RAMPrice) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{34} }
 
 func (m *RAMPrice) GetPrice() float64 {
 	if m != nil {
@@ -635,7 +1671,7 @@ type Balances struct {
 func (m *Balances) Reset()                    { *m = Balances{} }
 func (m *Balances) String() string            { return proto1.CompactTextString(m) }
 func (*Balances) ProtoMessage()               {}
-func (*Balances) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{18} }
+func (*Balances) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{35} }
 
 func (m *Balances) GetAccount() string {
 	if m != nil {
@@ -662,7 +1698,7 @@ type ChainState struct {
 func (m *ChainState) Reset()                    { *m = ChainState{} }
 func (m *ChainState) String() string            { return proto1.CompactTextString(m) }
 func (*ChainState) ProtoMessage()               {}
-func (*ChainState) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{19} }
+func (*ChainState) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{36} }
 
 func (m *ChainState) GetHeadBlockNum() uint32 {
 	if m != nil {
@@ -706,7 +1742,7 @@ type Accounts struct {
 func (m *Accounts) Reset()                    { *m = Accounts{} }
 func (m *Accounts) String() string            { return proto1.CompactTextString(m) }
 func (*Accounts) ProtoMessage()               {}
-func (*Accounts) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{20} }
+func (*Accounts) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{37} }
 
 func (m *Accounts) GetAccountNames() []string {
 	if m != nil {
@@ -722,7 +1758,7 @@ type PublicKey struct {
 func (m *PublicKey) Reset()                    { *m = PublicKey{} }
 func (m *PublicKey) String() string            { return proto1.CompactTextString(m) }
 func (*PublicKey) ProtoMessage()               {}
-func (*PublicKey) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{21} }
+func (*PublicKey) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{38} }
 
 func (m *PublicKey) GetPublicKey() string {
 	if m != nil {
@@ -738,12 +1774,29 @@ func init() {
 	proto1.RegisterType((*AddressExtended)(nil), "proto.AddressExtended")
 	proto1.RegisterType((*ReplyInfo)(nil), "proto.ReplyInfo")
 	proto1.RegisterType((*WatchAddress)(nil), "proto.WatchAddress")
+	proto1.RegisterType((*UserID)(nil), "proto.UserID")
+	proto1.RegisterType((*NewTxReq)(nil), "proto.NewTxReq")
+	proto1.RegisterType((*NewTxStream)(nil), "proto.NewTxStream")
+	proto1.RegisterType((*NewTxStreamsList)(nil), "proto.NewTxStreamsList")
 	proto1.RegisterType((*BlockHeight)(nil), "proto.BlockHeight")
 	proto1.RegisterType((*AddressToResync)(nil), "proto.AddressToResync")
+	proto1.RegisterType((*ResyncJobID)(nil), "proto.ResyncJobID")
+	proto1.RegisterType((*ResyncProgress)(nil), "proto.ResyncProgress")
+	proto1.RegisterType((*ResyncJobsList)(nil), "proto.ResyncJobsList")
+	proto1.RegisterType((*AccountHistoryReq)(nil), "proto.AccountHistoryReq")
+	proto1.RegisterType((*AccountHistory)(nil), "proto.AccountHistory")
 	proto1.RegisterType((*Balance)(nil), "proto.Balance")
 	proto1.RegisterType((*RawTx)(nil), "proto.RawTx")
 	proto1.RegisterType((*SendTxResp)(nil), "proto.SendTxResp")
 	proto1.RegisterType((*Action)(nil), "proto.Action")
+	proto1.RegisterType((*Proposal)(nil), "proto.Proposal")
+	proto1.RegisterType((*ProposedAction)(nil), "proto.ProposedAction")
+	proto1.RegisterType((*PermissionLevel)(nil), "proto.PermissionLevel")
+	proto1.RegisterType((*Permission)(nil), "proto.Permission")
+	proto1.RegisterType((*KeyWeight)(nil), "proto.KeyWeight")
+	proto1.RegisterType((*PermissionLevelWeight)(nil), "proto.PermissionLevelWeight")
+	proto1.RegisterType((*WaitWeight)(nil), "proto.WaitWeight")
+	proto1.RegisterType((*PermissionLink)(nil), "proto.PermissionLink")
 	proto1.RegisterType((*BalanceReq)(nil), "proto.BalanceReq")
 	proto1.RegisterType((*Account)(nil), "proto.Account")
 	proto1.RegisterType((*Asset)(nil), "proto.Asset")
@@ -754,7 +1807,12 @@ func init() {
 	proto1.RegisterType((*ChainState)(nil), "proto.ChainState")
 	proto1.RegisterType((*Accounts)(nil), "proto.Accounts")
 	proto1.RegisterType((*PublicKey)(nil), "proto.PublicKey")
+	proto1.RegisterEnum("proto.AddressToResync_Source", AddressToResync_Source_name, AddressToResync_Source_value)
+	proto1.RegisterEnum("proto.ResyncProgress_Status", ResyncProgress_Status_name, ResyncProgress_Status_value)
 	proto1.RegisterEnum("proto.Action_Type", Action_Type_name, Action_Type_value)
+	proto1.RegisterEnum("proto.Action_Status", Action_Status_name, Action_Status_value)
+	proto1.RegisterEnum("proto.Action_ReceiptStatus", Action_ReceiptStatus_name, Action_ReceiptStatus_value)
+	proto1.RegisterEnum("proto.Action_DeferredStatus", Action_DeferredStatus_name, Action_DeferredStatus_value)
 }
 
 // Reference imports to suppress errors if they are not otherwise used.
@@ -774,6 +1832,12 @@ type NodeCommunicationsClient interface {
 	InitialAdd(ctx context.Context, in *UsersData, opts ...grpc.CallOption) (*ReplyInfo, error)
 	// AddNewAddress add address for tracking
 	AddNewAddress(ctx context.Context, in *WatchAddress, opts ...grpc.CallOption) (*ReplyInfo, error)
+	// RemoveAddress stops address tracking
+	RemoveAddress(ctx context.Context, in *WatchAddress, opts ...grpc.CallOption) (*ReplyInfo, error)
+	// RemoveUser stops tracking all the addresses of user
+	RemoveUser(ctx context.Context, in *UserID, opts ...grpc.CallOption) (*ReplyInfo, error)
+	// ReplaceUsersData atomically replaces all tracked users
+	ReplaceUsersData(ctx context.Context, in *UsersData, opts ...grpc.CallOption) (*ReplyInfo, error)
 	// GetBlockHeight gets head block height
 	// (and additional info on chain state)
 	GetBlockHeight(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*BlockHeight, error)
@@ -783,14 +1847,30 @@ type NodeCommunicationsClient interface {
 	// ResyncAddress resyncs account action history
 	// Actions are pushed to NewTx stream
 	ResyncAddress(ctx context.Context, in *AddressToResync, opts ...grpc.CallOption) (*ReplyInfo, error)
+	// ResyncAddressStream resyncs account action history
+	// streaming job progress until it's finished
+	ResyncAddressStream(ctx context.Context, in *AddressToResync, opts ...grpc.CallOption) (NodeCommunications_ResyncAddressStreamClient, error)
+	// GetResyncJob gets resync job progress
+	GetResyncJob(ctx context.Context, in *ResyncJobID, opts ...grpc.CallOption) (*ResyncProgress, error)
+	// CancelResyncJob stops running resync job
+	CancelResyncJob(ctx context.Context, in *ResyncJobID, opts ...grpc.CallOption) (*ReplyInfo, error)
+	// ResyncJobs lists running and recently finished resync jobs
+	ResyncJobs(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*ResyncJobsList, error)
+	// GetAccountHistory gets stored actions of tracked account
+	// from the latest to the oldest
+	GetAccountHistory(ctx context.Context, in *AccountHistoryReq, opts ...grpc.CallOption) (*AccountHistory, error)
 	// NewBlock streams new block's info
 	NewBlock(ctx context.Context, in *Empty, opts ...grpc.CallOption) (NodeCommunications_NewBlockClient, error)
 	// SendRawTx pushes transaction to chain
 	SendRawTx(ctx context.Context, in *RawTx, opts ...grpc.CallOption) (*SendTxResp, error)
 	// NewTx streams new actions data
-	NewTx(ctx context.Context, in *Empty, opts ...grpc.CallOption) (NodeCommunications_NewTxClient, error)
+	// starting with requested block or cursor
+	NewTx(ctx context.Context, in *NewTxReq, opts ...grpc.CallOption) (NodeCommunications_NewTxClient, error)
+	// NewTxStreams gets delivery counters of every NewTx stream
+	NewTxStreams(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*NewTxStreamsList, error)
 	// SyncState all the tracked account actions
 	// starts with BlockHeight
+	// Deprecated: use NewTxReq start_block_num or cursor
 	SyncState(ctx context.Context, in *BlockHeight, opts ...grpc.CallOption) (*ReplyInfo, error)
 	// GetChainState gets current blockchain state info
 	GetChainState(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*ChainState, error)
@@ -842,6 +1922,33 @@ func (c *nodeCommunicationsClient) AddNewAddress(ctx context.Context, in *WatchA
 	return out, nil
 }
 
+func (c *nodeCommunicationsClient) RemoveAddress(ctx context.Context, in *WatchAddress, opts ...grpc.CallOption) (*ReplyInfo, error) {
+	out := new(ReplyInfo)
+	err := grpc.Invoke(ctx, "/proto.NodeCommunications/RemoveAddress", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *nodeCommunicationsClient) RemoveUser(ctx context.Context, in *UserID, opts ...grpc.CallOption) (*ReplyInfo, error) {
+	out := new(ReplyInfo)
+	err := grpc.Invoke(ctx, "/proto.NodeCommunications/RemoveUser", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *nodeCommunicationsClient) ReplaceUsersData(ctx context.Context, in *UsersData, opts ...grpc.CallOption) (*ReplyInfo, error) {
+	out := new(ReplyInfo)
+	err := grpc.Invoke(ctx, "/proto.NodeCommunications/ReplaceUsersData", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *nodeCommunicationsClient) GetBlockHeight(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*BlockHeight, error) {
 	out := new(BlockHeight)
 	err := grpc.Invoke(ctx, "/proto.NodeCommunications/GetBlockHeight", in, out, c.cc, opts...)
@@ -869,8 +1976,76 @@ func (c *nodeCommunicationsClient) ResyncAddress(ctx context.Context, in *Addres
 	return out, nil
 }
 
+func (c *nodeCommunicationsClient) ResyncAddressStream(ctx context.Context, in *AddressToResync, opts ...grpc.CallOption) (NodeCommunications_ResyncAddressStreamClient, error) {
+	stream, err := grpc.NewClientStream(ctx, &_NodeCommunications_serviceDesc.Streams[0], c.cc, "/proto.NodeCommunications/ResyncAddressStream", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &nodeCommunicationsResyncAddressStreamClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type NodeCommunications_ResyncAddressStreamClient interface {
+	Recv() (*ResyncProgress, error)
+	grpc.ClientStream
+}
+
+type nodeCommunicationsResyncAddressStreamClient struct {
+	grpc.ClientStream
+}
+
+func (x *nodeCommunicationsResyncAddressStreamClient) Recv() (*ResyncProgress, error) {
+	m := new(ResyncProgress)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *nodeCommunicationsClient) GetResyncJob(ctx context.Context, in *ResyncJobID, opts ...grpc.CallOption) (*ResyncProgress, error) {
+	out := new(ResyncProgress)
+	err := grpc.Invoke(ctx, "/proto.NodeCommunications/GetResyncJob", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *nodeCommunicationsClient) CancelResyncJob(ctx context.Context, in *ResyncJobID, opts ...grpc.CallOption) (*ReplyInfo, error) {
+	out := new(ReplyInfo)
+	err := grpc.Invoke(ctx, "/proto.NodeCommunications/CancelResyncJob", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *nodeCommunicationsClient) ResyncJobs(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*ResyncJobsList, error) {
+	out := new(ResyncJobsList)
+	err := grpc.Invoke(ctx, "/proto.NodeCommunications/ResyncJobs", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *nodeCommunicationsClient) GetAccountHistory(ctx context.Context, in *AccountHistoryReq, opts ...grpc.CallOption) (*AccountHistory, error) {
+	out := new(AccountHistory)
+	err := grpc.Invoke(ctx, "/proto.NodeCommunications/GetAccountHistory", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *nodeCommunicationsClient) NewBlock(ctx context.Context, in *Empty, opts ...grpc.CallOption) (NodeCommunications_NewBlockClient, error) {
-	stream, err := grpc.NewClientStream(ctx, &_NodeCommunications_serviceDesc.Streams[0], c.cc, "/proto.NodeCommunications/NewBlock", opts...)
+	stream, err := grpc.NewClientStream(ctx, &_NodeCommunications_serviceDesc.Streams[1], c.cc, "/proto.NodeCommunications/NewBlock", opts...)
 	if err != nil {
 		return nil, err
 	}
@@ -910,8 +2085,8 @@ func (c *nodeCommunicationsClient) SendRawTx(ctx context.Context, in *RawTx, opt
 	return out, nil
 }
 
-func (c *nodeCommunicationsClient) NewTx(ctx context.Context, in *Empty, opts ...grpc.CallOption) (NodeCommunications_NewTxClient, error) {
-	stream, err := grpc.NewClientStream(ctx, &_NodeCommunications_serviceDesc.Streams[1], c.cc, "/proto.NodeCommunications/NewTx", opts...)
+func (c *nodeCommunicationsClient) NewTx(ctx context.Context, in *NewTxReq, opts ...grpc.CallOption) (NodeCommunications_NewTxClient, error) {
+	stream, err := grpc.NewClientStream(ctx, &_NodeCommunications_serviceDesc.Streams[2], c.cc, "/proto.NodeCommunications/NewTx", opts...)
 	if err != nil {
 		return nil, err
 	}
@@ -942,6 +2117,15 @@ func (x *nodeCommunicationsNewTxClient) Recv() (*Action, error) {
 	return m, nil
 }
 
+func (c *nodeCommunicationsClient) NewTxStreams(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*NewTxStreamsList, error) {
+	out := new(NewTxStreamsList)
+	err := grpc.Invoke(ctx, "/proto.NodeCommunications/NewTxStreams", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *nodeCommunicationsClient) SyncState(ctx context.Context, in *BlockHeight, opts ...grpc.CallOption) (*ReplyInfo, error) {
 	out := new(ReplyInfo)
 	err := grpc.Invoke(ctx, "/proto.NodeCommunications/SyncState", in, out, c.cc, opts...)
@@ -1014,6 +2198,12 @@ type NodeCommunicationsServer interface {
 	InitialAdd(context.Context, *UsersData) (*ReplyInfo, error)
 	// AddNewAddress add address for tracking
 	AddNewAddress(context.Context, *WatchAddress) (*ReplyInfo, error)
+	// RemoveAddress stops address tracking
+	RemoveAddress(context.Context, *WatchAddress) (*ReplyInfo, error)
+	// RemoveUser stops tracking all the addresses of user
+	RemoveUser(context.Context, *UserID) (*ReplyInfo, error)
+	// ReplaceUsersData atomically replaces all tracked users
+	ReplaceUsersData(context.Context, *UsersData) (*ReplyInfo, error)
 	// GetBlockHeight gets head block height
 	// (and additional info on chain state)
 	GetBlockHeight(context.Context, *Empty) (*BlockHeight, error)
@@ -1023,14 +2213,30 @@ type NodeCommunicationsServer interface {
 	// ResyncAddress resyncs account action history
 	// Actions are pushed to NewTx stream
 	ResyncAddress(context.Context, *AddressToResync) (*ReplyInfo, error)
+	// ResyncAddressStream resyncs account action history
+	// streaming job progress until it's finished
+	ResyncAddressStream(*AddressToResync, NodeCommunications_ResyncAddressStreamServer) error
+	// GetResyncJob gets resync job progress
+	GetResyncJob(context.Context, *ResyncJobID) (*ResyncProgress, error)
+	// CancelResyncJob stops running resync job
+	CancelResyncJob(context.Context, *ResyncJobID) (*ReplyInfo, error)
+	// ResyncJobs lists running and recently finished resync jobs
+	ResyncJobs(context.Context, *Empty) (*ResyncJobsList, error)
+	// GetAccountHistory gets stored actions of tracked account
+	// from the latest to the oldest
+	GetAccountHistory(context.Context, *AccountHistoryReq) (*AccountHistory, error)
 	// NewBlock streams new block's info
 	NewBlock(*Empty, NodeCommunications_NewBlockServer) error
 	// SendRawTx pushes transaction to chain
 	SendRawTx(context.Context, *RawTx) (*SendTxResp, error)
 	// NewTx streams new actions data
-	NewTx(*Empty, NodeCommunications_NewTxServer) error
+	// starting with requested block or cursor
+	NewTx(*NewTxReq, NodeCommunications_NewTxServer) error
+	// NewTxStreams gets delivery counters of every NewTx stream
+	NewTxStreams(context.Context, *Empty) (*NewTxStreamsList, error)
 	// SyncState all the tracked account actions
 	// starts with BlockHeight
+	// Deprecated: use NewTxReq start_block_num or cursor
 	SyncState(context.Context, *BlockHeight) (*ReplyInfo, error)
 	// GetChainState gets current blockchain state info
 	GetChainState(context.Context, *Empty) (*ChainState, error)
@@ -1105,6 +2311,60 @@ func _NodeCommunications_AddNewAddress_Handler(srv interface{}, ctx context.Cont
 	return interceptor(ctx, in, info, handler)
 }
 
+func _NodeCommunications_RemoveAddress_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(WatchAddress)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NodeCommunicationsServer).RemoveAddress(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/proto.NodeCommunications/RemoveAddress",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NodeCommunicationsServer).RemoveAddress(ctx, req.(*WatchAddress))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _NodeCommunications_RemoveUser_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UserID)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NodeCommunicationsServer).RemoveUser(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/proto.NodeCommunications/RemoveUser",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NodeCommunicationsServer).RemoveUser(ctx, req.(*UserID))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _NodeCommunications_ReplaceUsersData_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UsersData)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NodeCommunicationsServer).ReplaceUsersData(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/proto.NodeCommunications/ReplaceUsersData",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NodeCommunicationsServer).ReplaceUsersData(ctx, req.(*UsersData))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _NodeCommunications_GetBlockHeight_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(Empty)
 	if err := dec(in); err != nil {
@@ -1159,6 +2419,99 @@ func _NodeCommunications_ResyncAddress_Handler(srv interface{}, ctx context.Cont
 	return interceptor(ctx, in, info, handler)
 }
 
+func _NodeCommunications_ResyncAddressStream_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(AddressToResync)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(NodeCommunicationsServer).ResyncAddressStream(m, &nodeCommunicationsResyncAddressStreamServer{stream})
+}
+
+type NodeCommunications_ResyncAddressStreamServer interface {
+	Send(*ResyncProgress) error
+	grpc.ServerStream
+}
+
+type nodeCommunicationsResyncAddressStreamServer struct {
+	grpc.ServerStream
+}
+
+func (x *nodeCommunicationsResyncAddressStreamServer) Send(m *ResyncProgress) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _NodeCommunications_GetResyncJob_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ResyncJobID)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NodeCommunicationsServer).GetResyncJob(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/proto.NodeCommunications/GetResyncJob",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NodeCommunicationsServer).GetResyncJob(ctx, req.(*ResyncJobID))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _NodeCommunications_CancelResyncJob_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ResyncJobID)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NodeCommunicationsServer).CancelResyncJob(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/proto.NodeCommunications/CancelResyncJob",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NodeCommunicationsServer).CancelResyncJob(ctx, req.(*ResyncJobID))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _NodeCommunications_ResyncJobs_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NodeCommunicationsServer).ResyncJobs(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/proto.NodeCommunications/ResyncJobs",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NodeCommunicationsServer).ResyncJobs(ctx, req.(*Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _NodeCommunications_GetAccountHistory_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AccountHistoryReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NodeCommunicationsServer).GetAccountHistory(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/proto.NodeCommunications/GetAccountHistory",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NodeCommunicationsServer).GetAccountHistory(ctx, req.(*AccountHistoryReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _NodeCommunications_NewBlock_Handler(srv interface{}, stream grpc.ServerStream) error {
 	m := new(Empty)
 	if err := stream.RecvMsg(m); err != nil {
@@ -1199,7 +2552,7 @@ func _NodeCommunications_SendRawTx_Handler(srv interface{}, ctx context.Context,
 }
 
 func _NodeCommunications_NewTx_Handler(srv interface{}, stream grpc.ServerStream) error {
-	m := new(Empty)
+	m := new(NewTxReq)
 	if err := stream.RecvMsg(m); err != nil {
 		return err
 	}
@@ -1219,6 +2572,24 @@ func (x *nodeCommunicationsNewTxServer) Send(m *Action) error {
 	return x.ServerStream.SendMsg(m)
 }
 
+func _NodeCommunications_NewTxStreams_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NodeCommunicationsServer).NewTxStreams(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/proto.NodeCommunications/NewTxStreams",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NodeCommunicationsServer).NewTxStreams(ctx, req.(*Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _NodeCommunications_SyncState_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(BlockHeight)
 	if err := dec(in); err != nil {
@@ -1361,6 +2732,18 @@ var _NodeCommunications_serviceDesc = grpc.ServiceDesc{
 			MethodName: "AddNewAddress",
 			Handler:    _NodeCommunications_AddNewAddress_Handler,
 		},
+		{
+			MethodName: "RemoveAddress",
+			Handler:    _NodeCommunications_RemoveAddress_Handler,
+		},
+		{
+			MethodName: "RemoveUser",
+			Handler:    _NodeCommunications_RemoveUser_Handler,
+		},
+		{
+			MethodName: "ReplaceUsersData",
+			Handler:    _NodeCommunications_ReplaceUsersData_Handler,
+		},
 		{
 			MethodName: "GetBlockHeight",
 			Handler:    _NodeCommunications_GetBlockHeight_Handler,
@@ -1373,10 +2756,30 @@ var _NodeCommunications_serviceDesc = grpc.ServiceDesc{
 			MethodName: "ResyncAddress",
 			Handler:    _NodeCommunications_ResyncAddress_Handler,
 		},
+		{
+			MethodName: "GetResyncJob",
+			Handler:    _NodeCommunications_GetResyncJob_Handler,
+		},
+		{
+			MethodName: "CancelResyncJob",
+			Handler:    _NodeCommunications_CancelResyncJob_Handler,
+		},
+		{
+			MethodName: "ResyncJobs",
+			Handler:    _NodeCommunications_ResyncJobs_Handler,
+		},
+		{
+			MethodName: "GetAccountHistory",
+			Handler:    _NodeCommunications_GetAccountHistory_Handler,
+		},
 		{
 			MethodName: "SendRawTx",
 			Handler:    _NodeCommunications_SendRawTx_Handler,
 		},
+		{
+			MethodName: "NewTxStreams",
+			Handler:    _NodeCommunications_NewTxStreams_Handler,
+		},
 		{
 			MethodName: "SyncState",
 			Handler:    _NodeCommunications_SyncState_Handler,
@@ -1407,6 +2810,11 @@ var _NodeCommunications_serviceDesc = grpc.ServiceDesc{
 		},
 	},
 	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "ResyncAddressStream",
+			Handler:       _NodeCommunications_ResyncAddressStream_Handler,
+			ServerStreams: true,
+		},
 		{
 			StreamName:    "NewBlock",
 			Handler:       _NodeCommunications_NewBlock_Handler,
@@ -1424,85 +2832,186 @@ var _NodeCommunications_serviceDesc = grpc.ServiceDesc{
 func init() { proto1.RegisterFile("eos.proto", fileDescriptor0) }
 
 var fileDescriptor0 = []byte{
-	// 1274 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xb4, 0x56, 0xdf, 0x73, 0xdb, 0xc4,
-	0x13, 0xff, 0x2a, 0xb6, 0x13, 0x7b, 0x2d, 0x39, 0xce, 0x7d, 0xa1, 0x15, 0x69, 0x3b, 0x13, 0xd4,
-	0x1f, 0x53, 0x68, 0x27, 0x84, 0x64, 0x60, 0xa0, 0x0c, 0x0f, 0x4e, 0x6b, 0x82, 0x49, 0x6b, 0x3a,
-	0x67, 0x87, 0x4e, 0x9f, 0x3c, 0x67, 0x69, 0x9b, 0x68, 0x62, 0x49, 0x46, 0x3a, 0x27, 0xf1, 0x13,
-	0x6f, 0x0c, 0x7f, 0x02, 0xff, 0x13, 0xff, 0x04, 0x7f, 0x07, 0x4f, 0xcc, 0xad, 0xee, 0x14, 0xd9,
-	0xb8, 0x74, 0x06, 0x86, 0x27, 0xdd, 0x7e, 0x76, 0x6f, 0xf7, 0x73, 0xbb, 0x7b, 0xb7, 0x82, 0x06,
-	0x26, 0xd9, 0xee, 0x34, 0x4d, 0x64, 0xc2, 0x6a, 0xf4, 0xf1, 0x36, 0xa0, 0xd6, 0x8d, 0xa6, 0x72,
-	0xee, 0x5d, 0x41, 0x6b, 0x80, 0xe9, 0x45, 0xe8, 0xe3, 0x0f, 0x98, 0x66, 0x61, 0x12, 0xb3, 0x1b,
-	0xb0, 0x3e, 0x4e, 0x45, 0xec, 0x9f, 0xb9, 0xd6, 0x8e, 0xf5, 0xb0, 0xc1, 0xb5, 0xa4, 0x70, 0x3f,
-	0x89, 0xa2, 0x50, 0xba, 0x6b, 0x39, 0x9e, 0x4b, 0xec, 0x36, 0x34, 0xc6, 0xb3, 0x70, 0x12, 0xc8,
-	0x30, 0x42, 0xb7, 0x42, 0xaa, 0x6b, 0x80, 0xb9, 0xb0, 0x31, 0x11, 0x99, 0x94, 0xe2, 0xd4, 0xad,
-	0x92, 0xce, 0x88, 0xde, 0x2f, 0x16, 0x34, 0x4e, 0x32, 0x4c, 0xb3, 0x67, 0x42, 0x0a, 0xf6, 0x08,
-	0x2a, 0x91, 0x98, 0xba, 0xd6, 0x4e, 0xe5, 0x61, 0x73, 0xff, 0x83, 0x9c, 0xec, 0x6e, 0xa1, 0xde,
-	0x7d, 0x21, 0xa6, 0xdd, 0x58, 0xa6, 0x73, 0xae, 0xac, 0xb6, 0xfb, 0x50, 0x37, 0x00, 0x6b, 0x43,
-	0xe5, 0x1c, 0xe7, 0x9a, 0xab, 0x5a, 0xb2, 0xc7, 0x50, 0xbb, 0x10, 0x93, 0x19, 0x12, 0xcf, 0xe6,
-	0xfe, 0x0d, 0xed, 0xac, 0x13, 0x04, 0x29, 0x66, 0x59, 0xf7, 0x4a, 0x62, 0x1c, 0x60, 0xc0, 0x73,
-	0xa3, 0x27, 0x6b, 0x5f, 0x58, 0x5e, 0x02, 0x9b, 0x4b, 0x5a, 0x75, 0x5a, 0x15, 0xbd, 0xf7, 0xcc,
-	0x64, 0x61, 0x46, 0x12, 0xdb, 0x81, 0xe6, 0x2b, 0x31, 0x99, 0xa0, 0xec, 0xc5, 0x01, 0x5e, 0x51,
-	0x88, 0x1a, 0x6f, 0x5e, 0x5e, 0x43, 0xcc, 0x03, 0x5b, 0x3b, 0xcb, 0x4d, 0x2a, 0x64, 0x62, 0x8b,
-	0x12, 0xe6, 0xdd, 0x87, 0x06, 0xc7, 0xe9, 0x64, 0xde, 0x8b, 0xdf, 0x24, 0x2a, 0x45, 0x11, 0x66,
-	0x99, 0x38, 0x45, 0x1d, 0xcb, 0x88, 0xde, 0xcf, 0x16, 0xd8, 0xaf, 0x84, 0xf4, 0xcf, 0xb4, 0x43,
-	0x65, 0xaa, 0xfd, 0x18, 0x53, 0x2d, 0x2a, 0xbe, 0x39, 0x43, 0x53, 0x9d, 0xd5, 0x7c, 0x2b, 0xef,
-	0xe6, 0x5b, 0x5d, 0xc1, 0xf7, 0x27, 0x68, 0x1e, 0x4e, 0x12, 0xff, 0xfc, 0x5b, 0x0c, 0x4f, 0xcf,
-	0x24, 0xbb, 0x07, 0xad, 0x33, 0x14, 0xc1, 0x68, 0xac, 0xb0, 0x51, 0x3c, 0x8b, 0x88, 0x8d, 0xc3,
-	0x6d, 0x85, 0x92, 0x61, 0x7f, 0x16, 0x31, 0x0f, 0x9c, 0x92, 0x55, 0x18, 0x68, 0x66, 0xcd, 0xc2,
-	0xa8, 0x17, 0xb0, 0x07, 0xb0, 0x59, 0xb2, 0x29, 0x5a, 0xa8, 0xc2, 0x9d, 0xc2, 0x6a, 0x18, 0x46,
-	0xe8, 0x3d, 0x2a, 0x2a, 0x34, 0x4c, 0x38, 0x66, 0xf3, 0xd8, 0x7f, 0x7b, 0x2e, 0xbc, 0xbb, 0xb0,
-	0x71, 0x28, 0x26, 0x22, 0xf6, 0xa9, 0xfd, 0xf4, 0xd2, 0x18, 0x8d, 0x73, 0xd1, 0xfb, 0x08, 0x6a,
-	0x5c, 0x5c, 0x0e, 0xaf, 0x54, 0x86, 0x64, 0x2a, 0xe2, 0x4c, 0xf8, 0x32, 0x4c, 0x62, 0x32, 0xb3,
-	0x79, 0x19, 0xf2, 0x0e, 0x00, 0x06, 0x18, 0x07, 0xc3, 0x2b, 0x8e, 0xd9, 0x94, 0xdd, 0x87, 0x56,
-	0x49, 0xa9, 0xce, 0x95, 0x7b, 0x76, 0x4a, 0x68, 0x2f, 0xf0, 0x7e, 0xaf, 0xc0, 0x7a, 0x87, 0x84,
-	0xff, 0xb6, 0x97, 0xd8, 0x03, 0xa8, 0xca, 0xf9, 0x14, 0xa9, 0x6e, 0xad, 0x7d, 0x66, 0xba, 0x9d,
-	0x42, 0xef, 0x0e, 0xe7, 0x53, 0xe4, 0xa4, 0x67, 0x0c, 0xaa, 0x6f, 0xd2, 0x24, 0x72, 0x6b, 0xc4,
-	0x81, 0xd6, 0xac, 0x05, 0x6b, 0x32, 0x71, 0xd7, 0x09, 0x59, 0x93, 0x09, 0xbb, 0x07, 0xeb, 0x22,
-	0x4a, 0x66, 0xb1, 0x74, 0x37, 0xe8, 0xee, 0xd8, 0xc6, 0x5b, 0x96, 0xa1, 0xe4, 0x5a, 0xa7, 0x3c,
-	0x45, 0x18, 0x25, 0x6e, 0x3d, 0xf7, 0xa4, 0xd6, 0xea, 0x8c, 0x29, 0xd5, 0xc5, 0x6d, 0xec, 0x58,
-	0x0f, 0xeb, 0x5c, 0x4b, 0x2b, 0xb2, 0x05, 0x94, 0xe0, 0xc5, 0x6c, 0xb1, 0x0f, 0xc1, 0x36, 0x16,
-	0x74, 0xd0, 0x26, 0x35, 0x41, 0x53, 0xeb, 0xe9, 0x9c, 0xa5, 0x7a, 0xdb, 0x8b, 0xbd, 0x7f, 0x0b,
-	0x1a, 0xd7, 0x9d, 0xe8, 0x50, 0x27, 0xd6, 0xc7, 0xba, 0x0b, 0xbd, 0xd7, 0x50, 0x1d, 0xe6, 0xc7,
-	0x6f, 0x0d, 0x79, 0xa7, 0x3f, 0xf8, 0xa6, 0xcb, 0x47, 0xc3, 0xef, 0x8f, 0xbb, 0xfd, 0xf6, 0xff,
-	0xd8, 0x26, 0x34, 0x7b, 0x83, 0xc1, 0x49, 0x57, 0x03, 0x16, 0xdb, 0x02, 0xe7, 0xf0, 0xe4, 0xf5,
-	0x88, 0x77, 0x5e, 0x8c, 0x0e, 0x5f, 0x0f, 0xbb, 0x83, 0xf6, 0x1a, 0x6b, 0xc2, 0x86, 0x86, 0xda,
-	0x15, 0x66, 0x43, 0x7d, 0xd0, 0x7d, 0xfe, 0x9c, 0xa4, 0xaa, 0xc7, 0x01, 0x74, 0x73, 0x71, 0xfc,
-	0x91, 0xf8, 0xf9, 0x3e, 0x25, 0xcf, 0xf4, 0x63, 0x2e, 0xaa, 0xdc, 0x64, 0xf3, 0x68, 0x9c, 0x4c,
-	0xcc, 0xdd, 0xcc, 0x25, 0x95, 0x47, 0x3f, 0x09, 0xcc, 0xa3, 0x49, 0x6b, 0xef, 0x0e, 0x6c, 0x74,
-	0xf4, 0x36, 0x06, 0xd5, 0x58, 0x44, 0xa6, 0x71, 0x69, 0xed, 0x9d, 0x40, 0x8d, 0x6a, 0xa1, 0x7c,
-	0xea, 0x4a, 0x59, 0x94, 0x2a, 0x53, 0x9b, 0xdb, 0xd0, 0x98, 0xa6, 0xe8, 0x87, 0xea, 0x29, 0xa7,
-	0x70, 0x0e, 0xbf, 0x06, 0x4a, 0x4c, 0x2a, 0x65, 0x26, 0xde, 0xaf, 0x16, 0xb4, 0x75, 0xd8, 0xa7,
-	0x29, 0x0a, 0x49, 0x07, 0x5a, 0x11, 0x9f, 0xdd, 0x01, 0x50, 0x35, 0xb9, 0xc0, 0x91, 0x7a, 0x74,
-	0xf3, 0xe3, 0x34, 0x72, 0xe4, 0x18, 0xe7, 0xaa, 0x12, 0xc9, 0x65, 0x8c, 0x29, 0x69, 0xf3, 0x10,
-	0x75, 0x02, 0x94, 0xb2, 0x0d, 0x95, 0x54, 0x44, 0xd4, 0xa7, 0x55, 0xae, 0x96, 0x0a, 0xf1, 0xa7,
-	0x33, 0xea, 0xc8, 0x0a, 0x57, 0x4b, 0x85, 0xc4, 0x28, 0xa9, 0x23, 0x2b, 0x5c, 0x2d, 0xbd, 0x43,
-	0x68, 0x6a, 0x66, 0xf4, 0x58, 0xbe, 0x07, 0x35, 0xbc, 0x0a, 0xb3, 0xfc, 0xd8, 0x75, 0x9e, 0x0b,
-	0x8a, 0xd6, 0x74, 0x36, 0x9e, 0x84, 0x7e, 0x99, 0x56, 0x8e, 0x1c, 0xe3, 0xdc, 0xdb, 0x81, 0x3a,
-	0xef, 0xbc, 0x78, 0x99, 0x86, 0x3e, 0x2a, 0x07, 0x53, 0xb5, 0x20, 0x07, 0x16, 0xcf, 0x05, 0xef,
-	0x3b, 0xa8, 0xeb, 0x52, 0x66, 0x7f, 0x53, 0x48, 0x75, 0x3d, 0x54, 0xf6, 0x33, 0x77, 0x8d, 0xe6,
-	0xd4, 0xf2, 0xf5, 0x20, 0x9d, 0xf7, 0x87, 0x05, 0xf0, 0xf4, 0x4c, 0x84, 0xf1, 0x40, 0x0a, 0x89,
-	0xff, 0xe6, 0xb1, 0xb4, 0xff, 0xd1, 0x63, 0xc9, 0xbe, 0x86, 0x5b, 0x6a, 0xc8, 0x8e, 0xc2, 0x34,
-	0xc5, 0x0b, 0x35, 0xd5, 0xc7, 0x13, 0x2c, 0x85, 0xaf, 0x52, 0x78, 0x57, 0x99, 0xf4, 0x4a, 0x16,
-	0x05, 0x95, 0xaf, 0x60, 0xfb, 0x6d, 0xdb, 0xc3, 0x80, 0x8a, 0x65, 0xf3, 0x9b, 0x2b, 0x77, 0xf7,
-	0x02, 0xef, 0x13, 0xa8, 0xeb, 0x72, 0x65, 0xec, 0x2e, 0x38, 0x3a, 0x73, 0x23, 0xd5, 0x3c, 0x19,
-	0x4d, 0xf7, 0x06, 0xb7, 0x35, 0xd8, 0x57, 0x98, 0xf7, 0x31, 0x34, 0x5e, 0x9a, 0x42, 0x2d, 0xd5,
-	0xd1, 0x5a, 0xaa, 0xe3, 0xfe, 0x6f, 0xeb, 0xc0, 0xfa, 0x49, 0x80, 0x4f, 0x93, 0x28, 0x9a, 0xc5,
-	0xa1, 0x2f, 0xd4, 0xeb, 0x90, 0xb1, 0x7d, 0x68, 0xea, 0x7f, 0x18, 0x6a, 0x11, 0x53, 0x15, 0xfa,
-	0xc1, 0xd9, 0x7e, 0x5f, 0x4b, 0x4b, 0x7f, 0x39, 0x7b, 0x00, 0xbd, 0x38, 0x94, 0xa1, 0x98, 0x74,
-	0x82, 0x80, 0xb5, 0x97, 0x7f, 0x38, 0xb6, 0x0d, 0x72, 0x3d, 0xa6, 0x3f, 0x07, 0xa7, 0x13, 0x04,
-	0x7d, 0xbc, 0x34, 0xc3, 0xf8, 0xff, 0xda, 0xa4, 0x3c, 0xa1, 0x57, 0xec, 0xdb, 0x87, 0xd6, 0x11,
-	0xca, 0xf2, 0xf8, 0x5c, 0x24, 0x68, 0x5e, 0xec, 0xb2, 0xc5, 0x01, 0x6c, 0x1d, 0xa1, 0xd4, 0x3e,
-	0xcd, 0x2c, 0x6b, 0x15, 0x4f, 0x3b, 0xa5, 0x6f, 0xdb, 0xc8, 0x46, 0xff, 0x25, 0x38, 0xf9, 0x68,
-	0x34, 0x04, 0x97, 0xfe, 0x7c, 0xcc, 0xe4, 0x5c, 0xc1, 0x71, 0x17, 0xea, 0x7d, 0xbc, 0x24, 0x06,
-	0xef, 0x66, 0xb7, 0x67, 0xb1, 0xc7, 0xd0, 0x50, 0x13, 0x31, 0x1f, 0xa0, 0x66, 0x03, 0x49, 0xdb,
-	0x5b, 0x45, 0xbe, 0x8b, 0x89, 0xf9, 0x00, 0x6a, 0x7d, 0x2c, 0x5b, 0xe6, 0xae, 0x9d, 0x85, 0x51,
-	0xb5, 0x67, 0xb1, 0x4f, 0xa1, 0x31, 0x98, 0xc7, 0x7e, 0x7e, 0x6d, 0x56, 0x04, 0x5e, 0x41, 0x7c,
-	0x0f, 0x9c, 0x23, 0x94, 0xa5, 0xdb, 0xb6, 0x18, 0xc2, 0x90, 0x29, 0x19, 0x3c, 0x01, 0x67, 0xe1,
-	0xa5, 0x63, 0x37, 0x17, 0xd3, 0x5a, 0xbc, 0x7f, 0x2b, 0x4b, 0x69, 0x1b, 0xab, 0x33, 0xf4, 0xcf,
-	0xff, 0x52, 0x11, 0xb6, 0x28, 0xd3, 0x9e, 0xc7, 0xd0, 0x3c, 0x42, 0x59, 0x3c, 0x3f, 0x8b, 0xfc,
-	0x36, 0x4d, 0x08, 0xa3, 0xfe, 0x0c, 0x36, 0x8f, 0x50, 0x0e, 0x93, 0x73, 0x8c, 0x4d, 0x59, 0xb7,
-	0x16, 0xcb, 0xac, 0x98, 0x6d, 0x2e, 0x42, 0x19, 0x3b, 0xa0, 0x1e, 0x3b, 0xc6, 0x79, 0x71, 0xf7,
-	0x0c, 0xf9, 0xe2, 0x6e, 0x15, 0x9b, 0x8c, 0xc9, 0x78, 0x9d, 0xe4, 0x83, 0x3f, 0x03, 0x00, 0x00,
-	0xff, 0xff, 0x6c, 0x9b, 0xda, 0x91, 0x1e, 0x0c, 0x00, 0x00,
+	// 2893 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xb4, 0x39, 0xdb, 0x72, 0x1b, 0xc7,
+	0xb1, 0xc2, 0x1d, 0x68, 0x5c, 0xb8, 0x1c, 0xdd, 0xd6, 0x94, 0x64, 0xd3, 0x2b, 0xf9, 0x76, 0xac,
+	0x43, 0xcb, 0x94, 0x75, 0x8e, 0x2f, 0xe7, 0x54, 0x02, 0x12, 0x4b, 0x0a, 0x16, 0x05, 0x22, 0x03,
+	0x40, 0xb4, 0xfc, 0xb2, 0xb5, 0xdc, 0x1d, 0x91, 0x6b, 0x02, 0xbb, 0xf0, 0xee, 0x82, 0x24, 0xf2,
+	0x90, 0xbc, 0xe5, 0x03, 0xf2, 0x94, 0x97, 0x7c, 0x49, 0xbe, 0x24, 0x55, 0xae, 0x4a, 0xfe, 0x21,
+	0x55, 0x49, 0x55, 0x9e, 0x52, 0x3d, 0x97, 0xc5, 0x2e, 0x04, 0xca, 0x89, 0x53, 0x79, 0xc2, 0xf6,
+	0x65, 0x7a, 0x7a, 0xba, 0x7b, 0xba, 0x7b, 0x1a, 0x50, 0x63, 0x41, 0xb4, 0x35, 0x0d, 0x83, 0x38,
+	0x20, 0x25, 0xfe, 0x63, 0x54, 0xa0, 0x64, 0x4e, 0xa6, 0xf1, 0xdc, 0xb8, 0x84, 0xd6, 0x80, 0x85,
+	0xe7, 0x9e, 0xc3, 0x5e, 0xb0, 0x30, 0xf2, 0x02, 0x9f, 0xdc, 0x82, 0xf2, 0x71, 0x68, 0xfb, 0xce,
+	0xa9, 0x9e, 0xdb, 0xcc, 0x7d, 0x58, 0xa3, 0x12, 0x42, 0xbc, 0x13, 0x4c, 0x26, 0x5e, 0xac, 0xe7,
+	0x05, 0x5e, 0x40, 0xe4, 0x2e, 0xd4, 0x8e, 0x67, 0xde, 0xd8, 0x8d, 0xbd, 0x09, 0xd3, 0x0b, 0x9c,
+	0xb4, 0x40, 0x10, 0x1d, 0x2a, 0x63, 0x3b, 0x8a, 0x63, 0xfb, 0x44, 0x2f, 0x72, 0x9a, 0x02, 0x8d,
+	0x3f, 0xe4, 0xa0, 0x36, 0x8a, 0x58, 0x18, 0x75, 0xec, 0xd8, 0x26, 0x1f, 0x43, 0x61, 0x62, 0x4f,
+	0xf5, 0xdc, 0x66, 0xe1, 0xc3, 0xfa, 0xf6, 0x5b, 0x42, 0xd9, 0xad, 0x84, 0xbc, 0xf5, 0xdc, 0x9e,
+	0x9a, 0x7e, 0x1c, 0xce, 0x29, 0x72, 0x91, 0x4f, 0xa1, 0x66, 0xbb, 0x6e, 0xc8, 0xa2, 0x88, 0x45,
+	0x7a, 0x9e, 0x2f, 0xb9, 0x2e, 0x97, 0x1c, 0xd9, 0xb1, 0x73, 0xda, 0x16, 0x44, 0xba, 0xe0, 0xda,
+	0xe8, 0x41, 0x55, 0xc9, 0x20, 0x1a, 0x14, 0xce, 0xd8, 0x5c, 0x1e, 0x0f, 0x3f, 0xc9, 0x43, 0x28,
+	0x9d, 0xdb, 0xe3, 0x19, 0xe3, 0x47, 0xab, 0x6f, 0xdf, 0x92, 0xc2, 0xa4, 0x1c, 0xf3, 0x32, 0x66,
+	0xbe, 0xcb, 0x5c, 0x2a, 0x98, 0xbe, 0xcc, 0x7f, 0x9e, 0x33, 0x02, 0x58, 0x5b, 0xa2, 0xa2, 0x81,
+	0x50, 0xe1, 0x6e, 0x47, 0x19, 0x6e, 0xc6, 0x21, 0xb2, 0x09, 0xf5, 0x23, 0x7b, 0x3c, 0x66, 0x71,
+	0xd7, 0x77, 0xd9, 0x25, 0xdf, 0xa2, 0x44, 0xeb, 0x17, 0x0b, 0x14, 0x31, 0xa0, 0x21, 0x85, 0x09,
+	0x96, 0x02, 0x67, 0x69, 0xd8, 0x29, 0x9c, 0xf1, 0x1e, 0xd4, 0x28, 0x9b, 0x8e, 0xe7, 0x5d, 0xff,
+	0x55, 0x80, 0x56, 0x9d, 0xb0, 0x28, 0xb2, 0x4f, 0x98, 0xdc, 0x4b, 0x81, 0xc6, 0x6f, 0x72, 0xd0,
+	0x48, 0xdb, 0x00, 0x59, 0xa5, 0x1c, 0xc5, 0x2a, 0x41, 0xd4, 0x57, 0x68, 0xa8, 0x1c, 0xba, 0x5a,
+	0xdf, 0xc2, 0x8f, 0xeb, 0x5b, 0x5c, 0xa1, 0xef, 0xa6, 0xb2, 0x46, 0x6a, 0x9f, 0x8c, 0x5d, 0x8c,
+	0xdf, 0xe7, 0xa0, 0xda, 0x63, 0x17, 0xc3, 0x4b, 0xca, 0xbe, 0x27, 0xef, 0xc3, 0x5a, 0x14, 0xdb,
+	0x61, 0x6c, 0x1d, 0x8f, 0x03, 0xe7, 0xcc, 0xf2, 0x67, 0x13, 0xce, 0xdd, 0xa4, 0x4d, 0x8e, 0xde,
+	0x41, 0x6c, 0x6f, 0x36, 0x21, 0x0f, 0xa0, 0x95, 0xe6, 0xf3, 0x5c, 0xa9, 0x7c, 0x63, 0xc1, 0xd6,
+	0xe5, 0xae, 0x70, 0x66, 0x61, 0x14, 0x84, 0x32, 0x20, 0x25, 0x44, 0x3e, 0x86, 0x75, 0x2f, 0x0c,
+	0xd9, 0x39, 0x86, 0xfa, 0xf1, 0x98, 0x59, 0x81, 0x3f, 0x9e, 0x73, 0xed, 0xab, 0x54, 0x4b, 0x13,
+	0x0e, 0xfd, 0xf1, 0xdc, 0xf8, 0x15, 0xd4, 0xb9, 0x7a, 0x83, 0x38, 0x64, 0xf6, 0x84, 0x10, 0x28,
+	0xfa, 0xf6, 0x44, 0x19, 0x9c, 0x7f, 0x63, 0x24, 0x8d, 0xed, 0x13, 0xae, 0x42, 0x91, 0xe2, 0x27,
+	0xb9, 0x0d, 0x95, 0x89, 0x7d, 0x69, 0x21, 0xb6, 0xc0, 0xb1, 0xe5, 0x89, 0x7d, 0x79, 0x60, 0x9f,
+	0xa0, 0x4a, 0xdf, 0xcf, 0xd8, 0x8c, 0xb9, 0x7c, 0xbf, 0x22, 0x95, 0x10, 0xfa, 0xc7, 0x0d, 0x83,
+	0xe9, 0x94, 0xb9, 0x7a, 0x89, 0x13, 0x14, 0x68, 0xfc, 0x1c, 0xb4, 0xd4, 0xfe, 0xd1, 0x81, 0x17,
+	0xc5, 0xe4, 0x21, 0x54, 0x22, 0x01, 0xca, 0xab, 0x42, 0x64, 0xa8, 0xa6, 0x38, 0xa9, 0x62, 0x31,
+	0x7e, 0x0d, 0x75, 0x6e, 0x91, 0xa7, 0xcc, 0x3b, 0x39, 0x8d, 0xd1, 0x76, 0xa7, 0xcc, 0x76, 0x5f,
+	0x33, 0x71, 0x03, 0xb1, 0x89, 0x85, 0x0d, 0x68, 0xa6, 0xb8, 0x12, 0x03, 0xd7, 0x13, 0xa6, 0xae,
+	0x8b, 0xde, 0x4a, 0xf1, 0x24, 0x37, 0xbf, 0x40, 0x9b, 0x09, 0xd7, 0xd0, 0x9b, 0x30, 0xe3, 0x2f,
+	0xb9, 0xe4, 0x9a, 0x0c, 0x03, 0xca, 0xa2, 0xb9, 0xef, 0xbc, 0x21, 0x20, 0xdf, 0x81, 0x7a, 0xca,
+	0xb7, 0x7c, 0xdf, 0x26, 0x85, 0x85, 0x63, 0xc9, 0x1d, 0xa8, 0x31, 0x5f, 0xee, 0xca, 0x37, 0x6c,
+	0xd2, 0x2a, 0xf3, 0xc5, 0x7e, 0xe4, 0x3e, 0x34, 0x5f, 0x85, 0xc1, 0xc4, 0x72, 0x42, 0x66, 0xc7,
+	0x5e, 0xe0, 0x4b, 0xbf, 0x36, 0x10, 0xb9, 0x2b, 0x71, 0xe4, 0x09, 0x94, 0xa3, 0x60, 0x16, 0x3a,
+	0x8c, 0x1b, 0xbb, 0xb5, 0x7d, 0x2f, 0x7b, 0xd3, 0x95, 0x92, 0x5b, 0x03, 0xce, 0x44, 0x25, 0xb3,
+	0xf1, 0x10, 0xca, 0x02, 0x43, 0xaa, 0x50, 0x6c, 0x8f, 0x86, 0x87, 0xda, 0x35, 0x52, 0x81, 0x42,
+	0x7f, 0xbb, 0xaf, 0xe5, 0xc8, 0x1a, 0xd4, 0x9f, 0x76, 0x07, 0xc3, 0x43, 0xfa, 0xd2, 0x6a, 0xf7,
+	0xbb, 0x5a, 0xde, 0xb8, 0x0f, 0x75, 0x21, 0xe6, 0xeb, 0xe0, 0xb8, 0xdb, 0x21, 0x37, 0xa0, 0xf4,
+	0x1d, 0x7e, 0xc8, 0xe3, 0x0a, 0xc0, 0xf8, 0x21, 0x0f, 0x2d, 0xc1, 0xd5, 0x0f, 0x83, 0x13, 0x7e,
+	0xfe, 0x95, 0x8c, 0x69, 0x7b, 0xe5, 0xb3, 0xf6, 0xfa, 0x0c, 0xca, 0x51, 0x6c, 0xc7, 0xb3, 0x88,
+	0xdb, 0xa2, 0xb5, 0x7d, 0x57, 0x1e, 0x26, 0x2b, 0x76, 0x6b, 0xc0, 0x79, 0xa8, 0xe4, 0x5d, 0xb6,
+	0x72, 0xf1, 0x35, 0x2b, 0xdf, 0x87, 0xa6, 0x33, 0x0b, 0x43, 0xe6, 0x2b, 0x96, 0x92, 0x88, 0x12,
+	0x89, 0x5c, 0xe1, 0x8a, 0xf2, 0xeb, 0xae, 0xb0, 0x1d, 0xb4, 0x77, 0x64, 0xbd, 0x0a, 0x66, 0xbe,
+	0xab, 0x57, 0x78, 0x64, 0x37, 0x24, 0x72, 0x0f, 0x71, 0x78, 0x5a, 0x16, 0x86, 0x41, 0xa8, 0x57,
+	0xc5, 0x69, 0x39, 0x60, 0xec, 0x41, 0x59, 0xe8, 0x4b, 0xea, 0x50, 0xa1, 0xa3, 0x5e, 0xaf, 0xdb,
+	0xdb, 0xd7, 0xae, 0xa1, 0xd9, 0x3b, 0x87, 0x3d, 0x53, 0xcb, 0x11, 0x80, 0xf2, 0x5e, 0xbb, 0x7b,
+	0x60, 0x76, 0xb4, 0x3c, 0x69, 0x42, 0x6d, 0xb7, 0xdd, 0xdb, 0x35, 0x0f, 0x10, 0x2c, 0x20, 0xe9,
+	0x17, 0x23, 0x73, 0x64, 0x76, 0xb4, 0xa2, 0xf1, 0x95, 0xb2, 0xee, 0xd7, 0xc1, 0xb1, 0xb8, 0x3a,
+	0x1f, 0x41, 0xf1, 0xbb, 0xe0, 0x58, 0xdd, 0x9b, 0x9b, 0x2b, 0x6d, 0x45, 0x39, 0x8b, 0xf1, 0xb7,
+	0x1c, 0xac, 0xb7, 0x1d, 0x27, 0x98, 0xf9, 0xf1, 0x53, 0x2f, 0x8a, 0x83, 0x70, 0x8e, 0x29, 0xea,
+	0xea, 0xc0, 0xfd, 0x10, 0x4a, 0xf1, 0x7c, 0x2a, 0x6b, 0x51, 0x2b, 0xb9, 0x93, 0x6d, 0x7e, 0xdc,
+	0xad, 0xe1, 0x7c, 0xca, 0xa8, 0x60, 0xc0, 0x2c, 0x10, 0xcd, 0x27, 0xc7, 0xc1, 0x58, 0x25, 0x26,
+	0x01, 0x91, 0x0d, 0xa8, 0x3a, 0x81, 0x1f, 0x87, 0xb6, 0x13, 0xcb, 0x3a, 0x99, 0xc0, 0xcb, 0x0e,
+	0x2b, 0xbd, 0xf9, 0x5a, 0x2c, 0xfb, 0xe2, 0x06, 0x94, 0xc6, 0x1e, 0x56, 0xed, 0x0a, 0x27, 0x08,
+	0x20, 0x95, 0x20, 0xab, 0xe9, 0x04, 0x69, 0x7c, 0x0b, 0xad, 0xec, 0xc1, 0xc9, 0x07, 0x50, 0x91,
+	0x6e, 0x93, 0x96, 0x6b, 0x66, 0x4e, 0x47, 0x15, 0x15, 0xd5, 0xf4, 0xd9, 0x65, 0x6c, 0x49, 0xb9,
+	0x22, 0x56, 0x01, 0x51, 0xbb, 0x42, 0xf6, 0x7d, 0xa8, 0xec, 0xd8, 0x63, 0xdb, 0x77, 0x78, 0x57,
+	0x20, 0x3f, 0x95, 0x29, 0x8f, 0x05, 0x68, 0x7c, 0x04, 0x25, 0x6a, 0x5f, 0x0c, 0x2f, 0xb1, 0x0a,
+	0xc5, 0xa1, 0xed, 0x47, 0x42, 0x3c, 0x67, 0x6b, 0xd0, 0x34, 0xca, 0x78, 0x0c, 0x30, 0x60, 0xbe,
+	0x8b, 0xf5, 0x23, 0x9a, 0x92, 0xf7, 0xa0, 0x95, 0x22, 0x62, 0xde, 0x12, 0x92, 0x9b, 0x29, 0x6c,
+	0xd7, 0x35, 0x7e, 0x68, 0x41, 0x59, 0x68, 0xfe, 0x9f, 0xad, 0xd7, 0xe4, 0x7d, 0x28, 0xa2, 0xcb,
+	0xb9, 0x37, 0x57, 0x87, 0x04, 0xa7, 0x63, 0x59, 0xc1, 0x0c, 0xc5, 0xdd, 0x5a, 0xa3, 0xfc, 0x9b,
+	0xb4, 0x20, 0x1f, 0x07, 0xdc, 0x93, 0x35, 0x9a, 0x8f, 0x03, 0xf2, 0x00, 0xca, 0xf6, 0x04, 0x9d,
+	0xc2, 0x9d, 0x58, 0xdf, 0x6e, 0x28, 0x69, 0x51, 0xc4, 0x62, 0x2a, 0x69, 0x28, 0x69, 0xc2, 0x26,
+	0x81, 0xf4, 0x28, 0xff, 0xc6, 0x33, 0x86, 0x3c, 0xc2, 0xf5, 0x1a, 0xcf, 0x86, 0x12, 0x5a, 0x61,
+	0x2d, 0xe0, 0x06, 0xce, 0x5a, 0x8b, 0xbc, 0x0b, 0x0d, 0xc5, 0xc1, 0x0f, 0x5a, 0xe7, 0x49, 0xbe,
+	0x2e, 0xe9, 0xfc, 0x9c, 0xa9, 0x5b, 0xd1, 0xc8, 0xde, 0x8a, 0x3b, 0x50, 0x5b, 0x54, 0x9a, 0xa6,
+	0x08, 0xcb, 0x63, 0x55, 0x65, 0x16, 0x01, 0xd8, 0xca, 0x54, 0xe8, 0x87, 0x49, 0x4e, 0x5b, 0xe3,
+	0x86, 0xbb, 0x91, 0x35, 0xdc, 0x52, 0x2e, 0x4b, 0x5f, 0x1b, 0x6d, 0xe9, 0xda, 0xbc, 0x0d, 0x05,
+	0x67, 0x3a, 0xd3, 0xd7, 0x57, 0x58, 0x0c, 0x09, 0x48, 0xf7, 0x59, 0xac, 0x93, 0x55, 0x74, 0x9f,
+	0xc5, 0x28, 0x9b, 0x1b, 0xe3, 0x15, 0x0b, 0xf5, 0xeb, 0xdc, 0x78, 0x09, 0x4c, 0x1e, 0x43, 0x7d,
+	0xca, 0xc2, 0x89, 0x17, 0x45, 0xfc, 0x62, 0xdc, 0xe0, 0x17, 0x63, 0x5d, 0xca, 0xe8, 0x27, 0x14,
+	0x9a, 0xe6, 0xc2, 0x04, 0x34, 0xf6, 0xfc, 0x33, 0xfd, 0xe6, 0x66, 0x2e, 0x95, 0x80, 0x16, 0xdc,
+	0x07, 0x9e, 0x7f, 0x46, 0x39, 0x0b, 0x5e, 0xda, 0x69, 0x18, 0x5c, 0xce, 0xf5, 0x5b, 0x22, 0x37,
+	0x72, 0x00, 0x3b, 0xed, 0x69, 0x18, 0xb8, 0x33, 0x87, 0x85, 0x91, 0x7e, 0x7b, 0xb3, 0x80, 0x9d,
+	0x76, 0x82, 0x20, 0x6f, 0x41, 0xd5, 0x8b, 0x2c, 0xb1, 0x4c, 0xe7, 0xfa, 0x56, 0xbc, 0xa8, 0xcf,
+	0x17, 0xaa, 0xd6, 0xe5, 0xad, 0x54, 0xeb, 0xf2, 0x7f, 0xd0, 0xb4, 0x67, 0xf1, 0x69, 0x10, 0x7a,
+	0xbf, 0x14, 0xe5, 0x72, 0x63, 0xb3, 0x90, 0x6a, 0x7d, 0x53, 0x6a, 0xb1, 0x73, 0x36, 0xa6, 0x59,
+	0x66, 0x94, 0xe8, 0xda, 0xb1, 0xad, 0xdf, 0x11, 0x12, 0xf1, 0x1b, 0x83, 0xc5, 0xf3, 0xc7, 0x9e,
+	0xcf, 0x2c, 0x97, 0x4d, 0xe3, 0x53, 0xfd, 0x2e, 0x77, 0x79, 0x5d, 0xe0, 0x3a, 0x88, 0x22, 0x5b,
+	0x70, 0x7d, 0x6a, 0xf3, 0xca, 0x92, 0x09, 0xab, 0x7b, 0x3c, 0xac, 0xd6, 0x05, 0xa9, 0x9d, 0x0a,
+	0xae, 0x0d, 0xa8, 0x86, 0xcc, 0x61, 0xde, 0x39, 0x0b, 0xf5, 0xb7, 0x85, 0x7f, 0x15, 0x4c, 0x76,
+	0xa0, 0xc5, 0xbf, 0xa7, 0xb1, 0x25, 0x23, 0xe6, 0x1d, 0x1e, 0x31, 0x77, 0xb2, 0x11, 0x43, 0x05,
+	0x8f, 0x0c, 0x9c, 0x66, 0x98, 0x06, 0xc9, 0x26, 0x34, 0x9c, 0xe9, 0xcc, 0x9a, 0x61, 0xeb, 0x6c,
+	0xcd, 0x22, 0x7d, 0x53, 0xe4, 0x56, 0x67, 0x3a, 0x1b, 0x21, 0x6a, 0x14, 0x61, 0xa7, 0xe3, 0xb3,
+	0x58, 0x72, 0x5c, 0x04, 0xa1, 0x1b, 0xe9, 0xef, 0x8a, 0xbe, 0xd4, 0x67, 0x31, 0x67, 0x3a, 0x42,
+	0x24, 0x31, 0x61, 0xcd, 0x65, 0xaf, 0x58, 0x18, 0x32, 0x57, 0xa9, 0x63, 0x64, 0x8a, 0xb2, 0x54,
+	0xa7, 0x23, 0x99, 0xa4, 0x3e, 0x2d, 0x37, 0x03, 0xa3, 0x0d, 0x31, 0x80, 0x31, 0x8f, 0xbe, 0x0a,
+	0x19, 0xd3, 0xef, 0x73, 0x47, 0xd6, 0x25, 0x6e, 0x2f, 0x64, 0x8c, 0x7c, 0x0c, 0xd5, 0x69, 0x18,
+	0x4c, 0x83, 0xc8, 0x1e, 0xeb, 0x0f, 0x78, 0x28, 0xad, 0x29, 0x9f, 0x49, 0x34, 0x4d, 0x18, 0x8c,
+	0xbf, 0xe6, 0xa1, 0x38, 0x14, 0x69, 0xa6, 0x35, 0xa4, 0xed, 0xde, 0x60, 0xcf, 0xa4, 0xd6, 0xf0,
+	0xf0, 0x99, 0xd9, 0xd3, 0xae, 0x61, 0xe3, 0xd2, 0x1d, 0x0c, 0x46, 0xa6, 0x44, 0xe4, 0xc8, 0x3a,
+	0x34, 0x77, 0x46, 0x2f, 0x2d, 0xda, 0x7e, 0x6e, 0xed, 0xbc, 0x1c, 0x9a, 0x03, 0x2d, 0x8f, 0x55,
+	0x58, 0xa2, 0xb4, 0x02, 0x69, 0x40, 0x75, 0x60, 0x1e, 0x1c, 0x70, 0xa8, 0x88, 0xcb, 0x3b, 0xe6,
+	0x81, 0xb9, 0xdf, 0x1e, 0x9a, 0xd6, 0xce, 0x91, 0x56, 0xc2, 0xe5, 0xa3, 0x5e, 0x1a, 0x55, 0xc6,
+	0x92, 0x4c, 0xcd, 0xbd, 0x51, 0xaf, 0xa3, 0x55, 0x90, 0xbf, 0x67, 0x1e, 0x59, 0xed, 0xdd, 0xdd,
+	0xc3, 0x51, 0x6f, 0xa8, 0x55, 0x11, 0x31, 0xea, 0x77, 0x90, 0xb7, 0x3d, 0x1a, 0x3e, 0xd5, 0x6a,
+	0x4a, 0xa2, 0x42, 0x00, 0x16, 0xf8, 0x83, 0x6e, 0xef, 0x99, 0x00, 0xeb, 0x7c, 0x41, 0x6f, 0x81,
+	0x68, 0xe0, 0x8e, 0x2f, 0x0e, 0x87, 0xa6, 0xd5, 0xa7, 0x87, 0x9d, 0xd1, 0xae, 0x49, 0xb5, 0x26,
+	0x2e, 0xa1, 0xe6, 0x3e, 0x62, 0xbe, 0x79, 0xa9, 0xb5, 0x90, 0x63, 0xf7, 0xa0, 0xdd, 0x7d, 0x6e,
+	0x51, 0xf3, 0xa8, 0x4d, 0x3b, 0x03, 0x6d, 0x0d, 0x8f, 0xb4, 0x6f, 0xf6, 0x4c, 0xda, 0xdd, 0xd5,
+	0x34, 0xa2, 0x41, 0xe3, 0xf9, 0xa0, 0xcb, 0xf9, 0xfb, 0x87, 0x03, 0x53, 0x5b, 0x4f, 0x30, 0xed,
+	0x7e, 0x9f, 0x1e, 0xbe, 0x30, 0x35, 0x82, 0xb6, 0xe3, 0x98, 0x51, 0x4f, 0xe1, 0xae, 0xa3, 0x2a,
+	0x1c, 0x27, 0xfa, 0x0f, 0xed, 0x06, 0xee, 0xcb, 0x11, 0xe6, 0x37, 0xe6, 0xae, 0x76, 0xd3, 0x78,
+	0x92, 0xee, 0x63, 0xfa, 0x66, 0xaf, 0x23, 0xfa, 0x18, 0x0d, 0x1a, 0x5d, 0x4a, 0xcd, 0x17, 0x26,
+	0x1d, 0x74, 0x77, 0x0e, 0xb0, 0x9f, 0x69, 0x40, 0x95, 0xc3, 0x43, 0xec, 0x68, 0x8c, 0x11, 0x34,
+	0x33, 0x01, 0x8b, 0x64, 0x94, 0x38, 0x42, 0xf2, 0x35, 0xdc, 0x64, 0x70, 0xb8, 0x37, 0xb4, 0xb0,
+	0x03, 0xd2, 0x72, 0x08, 0x3e, 0x6d, 0xd3, 0x8e, 0x00, 0xb9, 0xaf, 0x3a, 0xe6, 0x41, 0xfb, 0x25,
+	0x6f, 0x86, 0xea, 0x50, 0x31, 0xbf, 0xe9, 0x77, 0x29, 0xef, 0x86, 0x4e, 0xa0, 0x95, 0x0d, 0x3c,
+	0x54, 0xa4, 0x77, 0x38, 0xb4, 0x3a, 0xe6, 0x9e, 0x49, 0x29, 0x97, 0x7d, 0x03, 0x34, 0x05, 0x59,
+	0x4a, 0xe1, 0x1c, 0xb9, 0x09, 0xeb, 0x09, 0x36, 0x51, 0x24, 0x4f, 0x6e, 0x01, 0x49, 0xd0, 0xa9,
+	0x16, 0xcc, 0xf8, 0x73, 0x0e, 0xaa, 0x2a, 0x0c, 0xf1, 0xf6, 0x8a, 0x40, 0x64, 0xa1, 0x2c, 0xb1,
+	0x09, 0x8c, 0x2d, 0xa2, 0x0a, 0x52, 0x8b, 0xe7, 0x26, 0xf9, 0x8c, 0x53, 0xc8, 0x1e, 0xe6, 0xa8,
+	0xcf, 0xa0, 0x16, 0xb2, 0xef, 0x67, 0x2c, 0x8a, 0x99, 0xab, 0x17, 0xde, 0x98, 0x9f, 0x16, 0x8c,
+	0xe4, 0x93, 0x45, 0xc7, 0x52, 0xcc, 0xf4, 0x7a, 0x42, 0x31, 0xe6, 0x2e, 0x77, 0x2e, 0x0f, 0xa1,
+	0x34, 0x46, 0x21, 0xbc, 0x06, 0x5f, 0xbd, 0x85, 0x60, 0x32, 0x7e, 0x9b, 0x83, 0x56, 0x56, 0x52,
+	0xa6, 0x0c, 0xe5, 0x96, 0xca, 0x90, 0xca, 0xbd, 0xf9, 0x37, 0xe5, 0xde, 0xc2, 0x4f, 0xc9, 0xbd,
+	0xc5, 0x45, 0xee, 0x35, 0xf6, 0x61, 0x6d, 0x69, 0x15, 0xd6, 0x10, 0xdb, 0x89, 0x03, 0x65, 0x7a,
+	0x01, 0x90, 0xb7, 0x01, 0x16, 0x35, 0x49, 0x35, 0x69, 0x0b, 0x8c, 0xf1, 0xa7, 0x1c, 0xc0, 0x42,
+	0xd2, 0xca, 0x47, 0xef, 0x2d, 0x28, 0x8b, 0x4c, 0x2d, 0x97, 0x4b, 0x08, 0xcb, 0x53, 0x7c, 0x1a,
+	0xb2, 0xe8, 0x34, 0x18, 0xbb, 0xf2, 0x75, 0xb6, 0x40, 0x90, 0x07, 0x50, 0x3c, 0x63, 0x73, 0xe5,
+	0x12, 0x4d, 0x1e, 0xf5, 0x19, 0x9b, 0x1f, 0xf1, 0xc7, 0x29, 0xe5, 0x54, 0xf2, 0x39, 0x54, 0x6d,
+	0xd1, 0x7f, 0x46, 0x7a, 0x89, 0x73, 0xde, 0x5d, 0x6d, 0x14, 0xb9, 0x2a, 0xe1, 0x26, 0x1f, 0x40,
+	0xe9, 0xc2, 0xf6, 0xe2, 0x48, 0x2f, 0x67, 0x8a, 0xf1, 0x91, 0xed, 0xc5, 0x92, 0x57, 0xd0, 0x8d,
+	0x27, 0x50, 0x4b, 0x76, 0x5d, 0x31, 0x0a, 0xba, 0x05, 0xe5, 0x0b, 0x4e, 0x93, 0xef, 0x4f, 0x09,
+	0x19, 0x0c, 0x6e, 0xae, 0x54, 0xe1, 0xa7, 0xd9, 0x39, 0xb5, 0x4d, 0x21, 0xb3, 0xcd, 0xcf, 0x00,
+	0x16, 0x2a, 0x63, 0x4d, 0x47, 0xa5, 0xad, 0x88, 0x39, 0xf2, 0xad, 0x5e, 0x41, 0x78, 0xc0, 0x9c,
+	0x2b, 0xf5, 0xfc, 0x16, 0x5a, 0xd9, 0x96, 0x02, 0x7d, 0xe8, 0x04, 0x6e, 0xe2, 0x43, 0xfc, 0x46,
+	0x1c, 0xef, 0x4e, 0x65, 0x54, 0xe2, 0x37, 0xf6, 0xbd, 0x78, 0x89, 0xbc, 0x90, 0x4d, 0x98, 0x2f,
+	0xf4, 0xaa, 0xd1, 0x34, 0xca, 0xa0, 0x00, 0xb2, 0x6d, 0x57, 0xef, 0x21, 0x61, 0xfd, 0xe4, 0x3d,
+	0x24, 0xc0, 0xd4, 0x2b, 0x27, 0x9f, 0x79, 0xe5, 0x28, 0x4d, 0x0a, 0x0b, 0x4d, 0x8c, 0x7b, 0x50,
+	0x91, 0x2f, 0x8e, 0x55, 0xc1, 0x66, 0x8c, 0xa0, 0xc4, 0x7b, 0x32, 0x94, 0x29, 0x7b, 0xe0, 0x1c,
+	0xef, 0x16, 0x24, 0x24, 0x9a, 0x22, 0xe6, 0x78, 0x89, 0x9d, 0x9b, 0x74, 0x81, 0xb8, 0xea, 0xbd,
+	0x65, 0xfc, 0x2e, 0x07, 0x9a, 0xdc, 0x96, 0xcf, 0x06, 0xf8, 0x81, 0x56, 0x05, 0xfb, 0x3d, 0x00,
+	0x4c, 0x13, 0xe7, 0xcc, 0xc2, 0x38, 0x11, 0xc7, 0xa9, 0x09, 0xcc, 0x33, 0x36, 0xc7, 0x1e, 0x37,
+	0xb8, 0xf0, 0x59, 0xc8, 0xa9, 0x62, 0x8b, 0x2a, 0x47, 0x20, 0x51, 0x83, 0x42, 0x68, 0x4f, 0xe4,
+	0xbc, 0x07, 0x3f, 0x89, 0x26, 0x7a, 0xd2, 0x12, 0x3f, 0x01, 0x7e, 0x12, 0x4d, 0x74, 0xa1, 0x65,
+	0x81, 0xf1, 0x59, 0x6c, 0xec, 0x40, 0x5d, 0x6a, 0xc6, 0x47, 0x7d, 0xf8, 0x4c, 0xbe, 0xf4, 0x22,
+	0x71, 0xec, 0x2a, 0x15, 0x00, 0xaa, 0x35, 0x9d, 0x1d, 0x8f, 0x3d, 0x27, 0xad, 0x96, 0xc0, 0x3c,
+	0x63, 0x73, 0x63, 0x13, 0xaa, 0xb4, 0xfd, 0xbc, 0x1f, 0x7a, 0x0e, 0x13, 0xbd, 0xa4, 0x27, 0x5f,
+	0x5a, 0x39, 0x2a, 0x00, 0xe3, 0x6b, 0xa8, 0x4a, 0x57, 0x46, 0x6f, 0x70, 0x24, 0x3e, 0x3c, 0xd0,
+	0xfa, 0x6a, 0xca, 0xba, 0xfc, 0xf0, 0xe0, 0x34, 0xe3, 0xef, 0x39, 0x80, 0xdd, 0x53, 0xdb, 0xf3,
+	0xb1, 0xb6, 0xb0, 0x7f, 0x67, 0xcc, 0xd4, 0xf8, 0x49, 0x63, 0x26, 0xf2, 0xff, 0x70, 0x07, 0xa7,
+	0xca, 0x56, 0x66, 0xb6, 0xb7, 0xd8, 0x5e, 0x8c, 0x38, 0x74, 0x64, 0xe9, 0xa6, 0x38, 0x12, 0x55,
+	0xbe, 0x82, 0x8d, 0xab, 0x96, 0x7b, 0x62, 0x2a, 0xd7, 0xa0, 0xb7, 0x57, 0xae, 0xee, 0xba, 0xc6,
+	0x27, 0x50, 0x6d, 0xab, 0x1c, 0xc4, 0xe7, 0x1e, 0xfc, 0x9b, 0xd7, 0x34, 0xf1, 0x62, 0xae, 0xd1,
+	0x86, 0x44, 0x62, 0x4d, 0x8b, 0x8c, 0xff, 0x82, 0x5a, 0x5f, 0x39, 0x6a, 0xc9, 0x8f, 0xb9, 0x25,
+	0x3f, 0x6e, 0xff, 0x11, 0x80, 0xf4, 0x02, 0x97, 0xed, 0x06, 0x93, 0xc9, 0xcc, 0xf7, 0x1c, 0x5b,
+	0x14, 0xac, 0x6d, 0xa8, 0xcb, 0xa1, 0x3d, 0x0f, 0x11, 0xe5, 0x15, 0x3e, 0xd1, 0xdf, 0x50, 0xd5,
+	0x6e, 0x69, 0xac, 0xff, 0x08, 0xa0, 0xeb, 0x7b, 0xb1, 0x67, 0x8f, 0xdb, 0xae, 0x4b, 0xb4, 0xe5,
+	0x09, 0xfb, 0x86, 0x96, 0x0c, 0x44, 0xd4, 0x90, 0xf9, 0x7f, 0xa0, 0xd9, 0x76, 0xdd, 0x1e, 0xbb,
+	0x50, 0xa3, 0xe4, 0x55, 0x33, 0xf6, 0xd5, 0xeb, 0x28, 0x9b, 0x04, 0xe7, 0xec, 0x5f, 0x5c, 0xf7,
+	0xdf, 0x00, 0x62, 0x1d, 0x2a, 0x45, 0x9a, 0x29, 0x0d, 0xbb, 0x9d, 0x95, 0xdb, 0x68, 0x08, 0xd8,
+	0x0e, 0x4b, 0x0e, 0xf1, 0x4f, 0x1d, 0x6b, 0x1b, 0x5a, 0xfb, 0x2c, 0x4e, 0xcf, 0x45, 0xb3, 0xf6,
+	0x53, 0x4f, 0xf5, 0x34, 0xc7, 0x63, 0x58, 0xdf, 0x67, 0xb1, 0x54, 0x5d, 0x0d, 0x31, 0x5a, 0x49,
+	0x67, 0xcf, 0xbd, 0xbb, 0xa1, 0x60, 0x45, 0xff, 0x02, 0xed, 0x80, 0xaf, 0x6d, 0x65, 0x87, 0x5b,
+	0xab, 0x87, 0x8d, 0x2b, 0x74, 0xdc, 0x83, 0xeb, 0x99, 0xa5, 0x72, 0x04, 0x7d, 0x95, 0x80, 0xd5,
+	0xc3, 0xac, 0x47, 0x39, 0xf2, 0x05, 0x34, 0xf6, 0x59, 0x9c, 0x0c, 0xc2, 0x08, 0xc9, 0x30, 0xf2,
+	0xf1, 0xe4, 0x15, 0x8b, 0xc9, 0xff, 0xc2, 0xda, 0x2e, 0x1e, 0x63, 0xfc, 0xe6, 0xd5, 0xaf, 0xeb,
+	0xfe, 0x29, 0x40, 0xc2, 0x10, 0x5d, 0x11, 0x9b, 0x4b, 0xa3, 0xb9, 0x8e, 0x30, 0x6f, 0x76, 0xf0,
+	0xa4, 0x67, 0xcd, 0xbb, 0x18, 0xc4, 0x6d, 0xdc, 0x5c, 0x49, 0x21, 0x5b, 0xfc, 0xef, 0x04, 0x31,
+	0xf5, 0xfa, 0x51, 0x97, 0x3e, 0xca, 0x91, 0x87, 0x50, 0xc3, 0xf9, 0x91, 0x18, 0x37, 0xa9, 0x05,
+	0x1c, 0xda, 0x58, 0x4f, 0xee, 0x50, 0x32, 0x5f, 0xfa, 0x08, 0x4a, 0x7c, 0xc6, 0x4e, 0xd6, 0xd2,
+	0x13, 0x77, 0x54, 0x27, 0x3b, 0x10, 0x7b, 0x94, 0x23, 0x4f, 0xa0, 0x91, 0x1e, 0xdc, 0x2f, 0x29,
+	0x73, 0xfb, 0xf5, 0x89, 0xbd, 0xb0, 0xc2, 0xa7, 0x50, 0x1b, 0xcc, 0x7d, 0x47, 0x24, 0xd1, 0x15,
+	0x2a, 0xaf, 0xb0, 0xf5, 0x23, 0x68, 0xee, 0xb3, 0x38, 0x95, 0x7b, 0xb3, 0x5b, 0xa9, 0x63, 0xa4,
+	0x18, 0xbe, 0x84, 0x66, 0xa6, 0xee, 0x91, 0xdb, 0x59, 0x63, 0x26, 0xd5, 0x70, 0xe5, 0xcd, 0x69,
+	0x28, 0xae, 0x53, 0xe6, 0x9c, 0xbd, 0x76, 0x01, 0x48, 0x16, 0xe6, 0x6b, 0x1e, 0x42, 0x1d, 0x23,
+	0x50, 0x15, 0xa3, 0xac, 0x7e, 0xca, 0x94, 0x09, 0xf9, 0x09, 0xac, 0xed, 0xb3, 0x78, 0x18, 0x9c,
+	0x31, 0x5f, 0xdd, 0xa2, 0xf5, 0xec, 0xad, 0x42, 0xcd, 0xd6, 0xb2, 0xa8, 0x88, 0x3c, 0xe6, 0x57,
+	0xfa, 0x19, 0x9b, 0x27, 0x99, 0x58, 0x29, 0x9f, 0x64, 0xda, 0x64, 0x91, 0x62, 0x39, 0x2e, 0x73,
+	0xf8, 0xf1, 0x3f, 0x06, 0x00, 0x56, 0x17, 0x5e, 0x3f, 0x1d, 0x1d, 0x00, 0x00,
 }